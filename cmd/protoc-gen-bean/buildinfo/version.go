@@ -53,6 +53,11 @@ func (v Version) String() string {
 	return fmt.Sprintf("%v.%v.%v", v.Major, v.Minor, v.Patch)
 }
 
+// Semantic returns the semantic version, e.g. v0.3.0
+func Semantic() string {
+	return "v" + currentVersion.String()
+}
+
 // VersionString returns a string represents version and os info
 func VersionString() string {
 	var sb strings.Builder
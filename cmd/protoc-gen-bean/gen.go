@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/master-g/protoc-gen-bean/cmd/protoc-gen-bean/buildinfo"
+	"github.com/master-g/protoc-gen-bean/pkg/generator"
+)
+
+// runGen generates from a serialized FileDescriptorSet and writes the output files to disk,
+// the proto files given as arguments are generated, or every file of the set except the
+// well-known types when none is given.
+// With --check nothing is written, the files on disk are compared with the generated ones instead
+// and the program exits with status 1 if any of them is out of date or is stale, not generated any more
+// in a directory of generated files.
+func runGen(args []string) {
+	outdated, err := gen(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", generator.GeneratorName, err)
+		os.Exit(1)
+	}
+	if len(outdated) > 0 {
+		fmt.Fprintf(os.Stderr, "%s: %d file(s) out of date\n", generator.GeneratorName, len(outdated))
+		for _, s := range outdated {
+			fmt.Fprintln(os.Stderr, "  "+s)
+		}
+		os.Exit(1)
+	}
+}
+
+func gen(args []string) (outdated []string, err error) {
+	fs := flag.NewFlagSet("gen", flag.ExitOnError)
+	descriptorSet := fs.String("descriptor_set", "", "FileDescriptorSet written by protoc --descriptor_set_out --include_imports")
+	out := fs.String("out", ".", "output directory")
+	vopkg := fs.String("vopkg", "", "java package of the beans")
+	lang := fs.String("lang", "", "target language, kotlin or java")
+	param := fs.String("param", "", "comma-separated plugin parameters, as passed to --bean_out")
+	check := fs.Bool("check", false, "report files on disk which differ from the generated ones or are not generated instead of writing them")
+	timeout := fs.Duration("timeout", 0, "abort the generation after the duration, e.g. 30s, none when 0")
+	_ = fs.Parse(args)
+
+	if *descriptorSet == "" {
+		return nil, errors.New("missing --descriptor_set")
+	}
+	data, err := ioutil.ReadFile(*descriptorSet)
+	if err != nil {
+		return nil, fmt.Errorf("reading descriptor set: %v", err)
+	}
+	set := new(descriptor.FileDescriptorSet)
+	if err = proto.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("parsing descriptor set: %v", err)
+	}
+
+	req := &plugin.CodeGeneratorRequest{
+		ProtoFile:      set.File,
+		Parameter:      proto.String(*param),
+		FileToGenerate: fs.Args(),
+	}
+	if len(req.FileToGenerate) == 0 {
+		for _, f := range set.File {
+			if !strings.HasPrefix(f.GetName(), "google/protobuf/") {
+				req.FileToGenerate = append(req.FileToGenerate, f.GetName())
+			}
+		}
+	}
+
+	ctx := context.Background()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	resp, err := generator.RunContext(ctx, req, generator.Options{
+		Lang:    *lang,
+		Package: *vopkg,
+		Version: buildinfo.Semantic(),
+		Log:     os.Stderr,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	generated := make(map[string]bool)
+	for _, f := range resp.File {
+		name := filepath.Join(*out, filepath.FromSlash(f.GetName()))
+		if *check {
+			generated[name] = true
+			if s := checkFile(name, f.GetContent()); s != "" {
+				outdated = append(outdated, s)
+			}
+			continue
+		}
+		if err = os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			return nil, fmt.Errorf("creating output directory: %v", err)
+		}
+		if err = ioutil.WriteFile(name, []byte(f.GetContent()), 0644); err != nil {
+			return nil, fmt.Errorf("writing %s: %v", name, err)
+		}
+	}
+	if *check {
+		stale, err := staleFiles(generated)
+		if err != nil {
+			return nil, err
+		}
+		outdated = append(outdated, stale...)
+	}
+	return outdated, nil
+}
+
+// checkFile compares the file on disk with its generated content,
+// it returns a description of the difference or an empty string if they are the same.
+func checkFile(name, content string) string {
+	data, err := ioutil.ReadFile(name)
+	if os.IsNotExist(err) {
+		return name + ": missing"
+	}
+	if err != nil {
+		return name + ": " + err.Error()
+	}
+	if string(data) == content {
+		return ""
+	}
+	have := strings.Split(string(data), "\n")
+	want := strings.Split(content, "\n")
+	for i := 0; i < len(have) && i < len(want); i++ {
+		if have[i] != want[i] {
+			return fmt.Sprintf("%s: differs at line %d", name, i+1)
+		}
+	}
+	return fmt.Sprintf("%s: has %d lines, want %d", name, len(have), len(want))
+}
+
+// staleFiles returns the files of the directories of the generated files which are not generated themselves,
+// e.g. the beans of a message since deleted or renamed, subdirectories being left to their own files
+func staleFiles(generated map[string]bool) ([]string, error) {
+	dirs := make(map[string]bool)
+	for name := range generated {
+		dirs[filepath.Dir(name)] = true
+	}
+	var stale []string
+	for dir := range dirs {
+		entries, err := ioutil.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading output directory: %v", err)
+		}
+		for _, e := range entries {
+			name := filepath.Join(dir, e.Name())
+			if !e.IsDir() && !generated[name] {
+				stale = append(stale, name+": stale")
+			}
+		}
+	}
+	sort.Strings(stale)
+	return stale, nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// TestGenCheck checks --check reports the changed files and the stale ones left next to the generated files,
+// and nothing once the output is up to date
+func TestGenCheck(t *testing.T) {
+	dir := t.TempDir()
+	set := &descriptor.FileDescriptorSet{File: []*descriptor.FileDescriptorProto{{
+		Name:        proto.String("hello.proto"),
+		Package:     proto.String("example.hello"),
+		Syntax:      proto.String("proto3"),
+		MessageType: []*descriptor.DescriptorProto{{Name: proto.String("Hello")}},
+	}}}
+	data, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	descriptorSet := filepath.Join(dir, "hello.pb")
+	if err = ioutil.WriteFile(descriptorSet, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "out")
+	args := []string{"--descriptor_set=" + descriptorSet, "--out=" + out}
+	if _, err = gen(args); err != nil {
+		t.Fatal(err)
+	}
+	check := append([]string{"--check"}, args...)
+	outdated, err := gen(check)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outdated) > 0 {
+		t.Errorf("up to date output reported: %v", outdated)
+	}
+
+	beans := filepath.Join(out, "example", "hello", "vo")
+	bean := filepath.Join(beans, "Hello.kt")
+	stale := filepath.Join(beans, "Goodbye.kt")
+	if err = ioutil.WriteFile(bean, []byte("class Hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(stale, []byte("class Goodbye\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	outdated, err = gen(check)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{bean + ": differs at line 1", stale + ": stale"}
+	if !reflect.DeepEqual(outdated, want) {
+		t.Errorf("reported %v, want %v", outdated, want)
+	}
+}
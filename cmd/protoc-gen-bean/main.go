@@ -1,52 +1,113 @@
 package main
 
 import (
+	"bufio"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"log"
 	"os"
 
 	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/master-g/protoc-gen-bean/cmd/protoc-gen-bean/buildinfo"
 	"github.com/master-g/protoc-gen-bean/pkg/generator"
+	"google.golang.org/protobuf/encoding/protowire"
 )
 
+// responseFileField is the number of the file field of CodeGeneratorResponse
+const responseFileField = 15
+
+const usage = `protoc-gen-bean generates java or kotlin beans from proto files, run it through protoc:
+
+  protoc --plugin=protoc-gen-bean --bean_out=<parameter>,...:<output dir> *.proto
+
+or standalone from a descriptor set written by protoc --descriptor_set_out --include_imports:
+
+  protoc-gen-bean gen --descriptor_set=<file> --out=<output dir> [--vopkg=<package>] [--lang=kotlin|java] [--param=<parameter>,...] [--check] [--timeout=<duration>] [file.proto ...]
+
+Parameters:
+`
+
 func main() {
-	// Begin by allocating a generator. The request and response structures are stored there
-	// so we can do error handling easily - the response structure contains the field to
-	// report failure.
-	g := generator.New()
-
-	var data []byte
-	var err error
-	data, err = ioutil.ReadAll(os.Stdin)
-	if err != nil {
-		g.Error(err, "reading input")
+	// Invoked standalone rather than by protoc
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "--version", "-version", "-v", "version":
+			fmt.Println(generator.GeneratorName, buildinfo.VersionString())
+			return
+		case "--help", "-help", "-h", "help":
+			printUsage()
+			return
+		case "gen":
+			runGen(os.Args[2:])
+			return
+		}
 	}
-	// shared.Dump(data, "dump.txt")
-	// data = shared.ProtocDump
-
-	if err = proto.Unmarshal(data, g.Request); err != nil {
-		g.Error(err, "parsing input proto")
+	if fi, err := os.Stdin.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+		// stdin is a terminal, there is no request to read
+		printUsage()
+		return
 	}
 
-	if len(g.Request.FileToGenerate) == 0 {
-		g.Fail("no files to generate")
+	// the files are written to protoc as they are generated, failures are reported in the response
+	out := bufio.NewWriter(os.Stdout)
+	var resp *plugin.CodeGeneratorResponse
+	req, err := readRequest()
+	if err != nil {
+		resp = &plugin.CodeGeneratorResponse{Error: proto.String(err.Error())}
+	} else {
+		resp, _ = generator.Run(req, generator.Options{
+			Version: buildinfo.Semantic(),
+			Log:     os.Stderr,
+			Stream: func(f *plugin.CodeGeneratorResponse_File) error {
+				return writeResponseFile(out, f)
+			},
+		})
 	}
 
-	g.CommandLineParameters(g.Request.GetParameter())
-
-	// Create a wrapped version of the Descriptors and EnumDescriptors that
-	// point to the file that defines them.
-	g.WrapTypes()
-	g.BuildTypeNameMap()
-
-	g.GenerateAllFiles()
+	// Send back the rest of the results, concatenated messages are merged by protoc
+	data, err := proto.Marshal(resp)
+	if err != nil {
+		log.Fatalf("%s: failed to marshal output proto: %v", generator.GeneratorName, err)
+	}
+	if _, err = out.Write(data); err == nil {
+		err = out.Flush()
+	}
+	if err != nil {
+		log.Fatalf("%s: failed to write output proto: %v", generator.GeneratorName, err)
+	}
+}
 
-	// Send back the results.
-	data, err = proto.Marshal(g.Response)
+// writeResponseFile writes the file as the file field of a CodeGeneratorResponse
+func writeResponseFile(w io.Writer, f *plugin.CodeGeneratorResponse_File) error {
+	data, err := proto.Marshal(f)
 	if err != nil {
-		g.Error(err, "failed to marshal output proto")
+		return err
+	}
+	b := protowire.AppendTag(nil, responseFileField, protowire.BytesType)
+	b = protowire.AppendVarint(b, uint64(len(data)))
+	if _, err = w.Write(b); err != nil {
+		return err
 	}
-	_, err = os.Stdout.Write(data)
+	_, err = w.Write(data)
+	return err
+}
+
+// readRequest reads the request of protoc from stdin
+func readRequest() (*plugin.CodeGeneratorRequest, error) {
+	data, err := ioutil.ReadAll(os.Stdin)
 	if err != nil {
-		g.Error(err, "failed to write output proto")
+		return nil, fmt.Errorf("reading input: %v", err)
+	}
+	req := new(plugin.CodeGeneratorRequest)
+	if err = proto.Unmarshal(data, req); err != nil {
+		return nil, fmt.Errorf("parsing input proto: %v", err)
 	}
+	return req, nil
+}
+
+func printUsage() {
+	fmt.Print(usage)
+	generator.PrintParameters(os.Stdout)
 }
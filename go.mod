@@ -2,6 +2,8 @@ module github.com/master-g/protoc-gen-bean
 
 go 1.17
 
-require github.com/golang/protobuf v1.5.2
-
-require google.golang.org/protobuf v1.26.0 // indirect
+require (
+	github.com/golang/protobuf v1.5.2
+	google.golang.org/protobuf v1.26.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// archiveFileName is the name of the response file holding the archived sources
+const archiveFileName = "beans.srcjar"
+
+// archiveEpoch is the modification time of the archived sources, fixed for reproducible archives
+var archiveEpoch = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// parseArchive validates the archive parameter
+func (g *Generator) parseArchive(v string) string {
+	switch v {
+	case "", "none":
+		return ""
+	case "srcjar":
+		return v
+	default:
+		g.Fail("unknown archive format", v, ", use archive=srcjar")
+		return ""
+	}
+}
+
+// srcjar is the archive the generated files are added to as they are complete, see emit
+type srcjar struct {
+	buf bytes.Buffer
+	w   *zip.Writer
+}
+
+// openArchive starts archiving the generated files
+func (g *Generator) openArchive() {
+	g.archive = new(srcjar)
+	g.archive.w = zip.NewWriter(&g.archive.buf)
+}
+
+// archiveFile compresses the generated file into the archive
+func (g *Generator) archiveFile(f *plugin.CodeGeneratorResponse_File) {
+	fw, err := g.archive.w.CreateHeader(&zip.FileHeader{
+		Name:     f.GetName(),
+		Method:   zip.Deflate,
+		Modified: archiveEpoch,
+	})
+	if err != nil {
+		g.Error(err, "failed to archive", f.GetName())
+	}
+	if _, err = io.WriteString(fw, f.GetContent()); err != nil {
+		g.Error(err, "failed to archive", f.GetName())
+	}
+}
+
+// closeArchive outputs the srcjar holding the generated files in their place
+func (g *Generator) closeArchive() {
+	a := g.archive
+	g.archive = nil
+	if err := a.w.Close(); err != nil {
+		g.Error(err, "failed to archive generated files")
+	}
+	g.emit(&plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(archiveFileName),
+		Content: proto.String(a.buf.String()),
+	})
+}
@@ -3,14 +3,12 @@ package generator
 import (
 	"fmt"
 	"strings"
-
-	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 )
 
 func getFullPathComponents(g *Generator, f *FileDescriptor, typeName []string) []string {
 	p := make([]string, 0)
-	if g.ValueObjectPackage != "" {
-		p = append(p, strings.Split(g.ValueObjectPackage, ".")...)
+	if f.importPath != "" {
+		p = append(p, strings.Split(f.importPath.String(), ".")...)
 	}
 	// if f != nil && f.GetPackage() != "" {
 	// 	p = append(p, strings.Split(f.GetPackage(), ".")...)
@@ -20,147 +18,176 @@ func getFullPathComponents(g *Generator, f *FileDescriptor, typeName []string) [
 	return p
 }
 
-func descriptorPackagePath(g *Generator, d *Descriptor) string {
-	p := getFullPathComponents(g, d.file, d.TypeName())
-	if len(p) > 0 {
-		p = p[:len(p)-1]
+// insertionPoint returns a marker other protoc plugins can insert code at,
+// see CodeGeneratorResponse.File.insertion_point in plugin.proto
+func insertionPoint(scope, name string) string {
+	return "// @@protoc_insertion_point(" + scope + ":" + name + ")"
+}
+
+// maxFieldNumber is the largest field number allowed by protobuf
+const maxFieldNumber = 536870911
+
+// reservedComment documents the reserved numbers and names of a message or enum,
+// ranges are given with inclusive ends, e.g. [2 5 9] for reserved 2, 5 to 9
+func reservedComment(kind string, ranges [][2]int32, names []string) string {
+	lines := make([]string, 0, 2)
+	if len(ranges) > 0 {
+		numbers := make([]string, 0, len(ranges))
+		for _, r := range ranges {
+			switch {
+			case r[0] == r[1]:
+				numbers = append(numbers, fmt.Sprint(r[0]))
+			case r[1] >= maxFieldNumber:
+				numbers = append(numbers, fmt.Sprintf("%d to max", r[0]))
+			default:
+				numbers = append(numbers, fmt.Sprintf("%d to %d", r[0], r[1]))
+			}
+		}
+		lines = append(lines, "// Reserved "+kind+" numbers: "+strings.Join(numbers, ", "))
 	}
-	return strings.Join(p, ".")
+	if len(names) > 0 {
+		lines = append(lines, "// Reserved "+kind+" names: "+strings.Join(names, ", "))
+	}
+	return strings.Join(lines, "\n")
 }
 
-func descriptorImportPath(g *Generator, d *Descriptor) string {
-	p := getFullPathComponents(g, d.file, d.TypeName())
-	return strings.Join(p, ".")
+// messageReserved documents the reserved field numbers and names of the message
+func messageReserved(msg *Descriptor) string {
+	ranges := make([][2]int32, 0, len(msg.ReservedRange))
+	for _, r := range msg.ReservedRange {
+		// end is exclusive in message ranges
+		ranges = append(ranges, [2]int32{r.GetStart(), r.GetEnd() - 1})
+	}
+	return reservedComment("field", ranges, msg.ReservedName)
 }
 
-func enumPackagePath(g *Generator, enum *EnumDescriptor) string {
-	p := getFullPathComponents(g, enum.file, enum.TypeName())
-	if len(p) > 0 {
-		p = p[:len(p)-1]
+// enumReserved documents the reserved values and names of the enum
+func enumReserved(enum *EnumDescriptor) string {
+	ranges := make([][2]int32, 0, len(enum.ReservedRange))
+	for _, r := range enum.ReservedRange {
+		ranges = append(ranges, [2]int32{r.GetStart(), r.GetEnd()})
 	}
-	return strings.Join(p, ".")
+	return reservedComment("value", ranges, enum.ReservedName)
 }
 
-func enumImportPath(g *Generator, enum *EnumDescriptor) string {
-	p := getFullPathComponents(g, enum.file, enum.TypeName())
-	return strings.Join(p, ".")
+// enumTableThreshold is the number of values above which forNumber looks up a table instead of a when or switch chain
+const enumTableThreshold = 16
+
+// enumNumberTable reports whether forNumber of the enum looks up a table, and whether the table is an array
+// indexed by number rather than a map, which requires the numbers to be non-negative and mostly contiguous.
+// The length of the array is returned along.
+func enumNumberTable(enum *EnumDescriptor) (table, dense bool, length int) {
+	if len(enum.Value) <= enumTableThreshold {
+		return false, false, 0
+	}
+	var max int32
+	for _, v := range enum.Value {
+		if v.GetNumber() < 0 {
+			return true, false, 0
+		}
+		if v.GetNumber() > max {
+			max = v.GetNumber()
+		}
+	}
+	if int(max) >= 2*len(enum.Value) {
+		return true, false, 0
+	}
+	return true, true, int(max) + 1
 }
 
-func getOneofName(msg *Descriptor, field *descriptor.FieldDescriptorProto) string {
-	if field.OneofIndex != nil {
-		odp := msg.OneofDecl[int(*field.OneofIndex)]
-		return CamelCase(odp.GetName())
-	} else {
-		return ""
+// isRecursive reports whether the bean of the message can contain a bean of the same message,
+// directly or through other messages, e.g. a TreeNode with repeated TreeNode children
+func (g *Generator) isRecursive(msg *Descriptor) bool {
+	visited := make(map[*Descriptor]bool)
+	var reaches func(d *Descriptor) bool
+	reaches = func(d *Descriptor) bool {
+		for _, sub := range g.resolve(d).refs {
+			if sub == msg {
+				return true
+			}
+			if !visited[sub] {
+				visited[sub] = true
+				if reaches(sub) {
+					return true
+				}
+			}
+		}
+		return false
 	}
+	return reaches(msg)
 }
 
-type oneofSubField struct {
-	index int
-	field *descriptor.FieldDescriptorProto
+// beanName returns the bean class name of the message or enum, without the enclosing classes
+func (g *Generator) beanName(obj Object) string {
+	typeName := g.beanTypeName(obj)
+	return typeName[len(typeName)-1]
 }
 
-func (f *oneofSubField) getEnumName() string {
-	return strings.ToUpper(f.field.GetName())
+// beanBaseClass returns the class extended by the bean of the message, or an empty string
+func (g *Generator) beanBaseClass(msg *Descriptor) string {
+	return g.MessageOverrides[protoFullName(msg)].BaseClass
 }
 
-type oneofField struct {
-	name      string
-	field     *descriptor.FieldDescriptorProto
-	subFields []*oneofSubField
+// enumDefault returns the index of the value set as the default constant of the enum, or -1 when none is set
+func (g *Generator) enumDefault(enum *EnumDescriptor) int {
+	name := g.MessageOverrides[protoFullName(enum)].Default
+	if name == "" {
+		return -1
+	}
+	for i, v := range enum.Value {
+		if v.GetName() == name {
+			return i
+		}
+	}
+	values := make([]string, 0, len(enum.Value))
+	for _, v := range enum.Value {
+		values = append(values, v.GetName())
+	}
+	err := schemaError(enum.File(), enum.path, protoFullName(enum), "default constant", name, "is not a value of the enum")
+	err.Suggestion = "use one of " + strings.Join(values, ", ")
+	g.failWith(err)
+	return -1
 }
 
-func (f *oneofField) getCaseClassName() string {
-	return fmt.Sprintf("%vCase", strings.Title(f.name))
+// beanTypeName returns the bean class names of the object and the messages enclosing it, e.g. [User Address],
+// names are decorated with the configured bean prefix and suffix unless overridden per message.
+// The slice is shared by every caller and must not be modified.
+func (g *Generator) beanTypeName(obj Object) []string {
+	return g.resolve(obj).beanNames
 }
 
-func (f *oneofField) populate(g *Generator, d *Descriptor) {
-	if g.flavor == FlavorJava {
-		f.populateJava(g, d)
-	} else {
-		f.populateKotlin(g, d)
+// derivedBeanPackage returns the bean package of a file when no vopkg is given,
+// a sub package of the java package keeps the beans apart from the protobuf-java classes.
+func derivedBeanPackage(f *FileDescriptor) string {
+	pkg := paramToJavaPackage(protoJavaPackage(f))
+	if pkg == "" {
+		return derivedSubPackage
 	}
+	return pkg + "." + derivedSubPackage
 }
 
-func (f *oneofField) populateJava(g *Generator, d *Descriptor) {
-	if len(f.subFields) == 0 {
-		return
+func descriptorPackagePath(g *Generator, d *Descriptor) string {
+	p := getFullPathComponents(g, d.file, g.beanTypeName(d))
+	if len(p) > 0 {
+		p = p[:len(p)-1]
 	}
+	return strings.Join(p, ".")
+}
 
-	g.P("public enum ", f.getCaseClassName(), " {")
-	g.In()
-	for _, sf := range f.subFields {
-		g.P(sf.getEnumName(), "(", sf.field.Number, "),")
-	}
-	g.P(strings.ToUpper(f.name), "_NOT_SET(0);")
-	// companion
-	g.Newline()
-	g.P("public int code;")
-	g.Newline()
-	g.P(f.getCaseClassName(), "(int code) {")
-	g.In()
-	g.P("this.code = code;")
-	g.Out()
-	g.P("}")
-	g.Newline()
-	g.P("public static ", f.getCaseClassName(), " forNumber(int value) {")
-	g.In()
-	g.P("switch (value) {")
-	g.In()
-	for _, sf := range f.subFields {
-		g.P("case ", sf.field.Number, ":")
-		g.In()
-		g.P("return ", sf.getEnumName(), ";")
-		g.Out()
-	}
-	notSet := fmt.Sprintf("%v_%v", strings.ToUpper(f.name), "NOT_SET")
-	g.P("default:")
-	g.In()
-	g.P("return ", notSet, ";")
-	g.Out()
-	g.Out()
-	g.P("}")
-	g.Out()
-	g.P("}")
-	g.Out()
-	g.P("}")
-	g.P()
-	g.P("public ", f.getCaseClassName(), " ", f.name, "Case", " = ", f.getCaseClassName(), ".", notSet, ";")
-}
-
-func (f *oneofField) populateKotlin(g *Generator, d *Descriptor) {
-	if len(f.subFields) == 0 {
-		return
-	}
+func descriptorImportPath(g *Generator, d *Descriptor) string {
+	p := getFullPathComponents(g, d.file, g.beanTypeName(d))
+	return strings.Join(p, ".")
+}
 
-	g.P("enum class ", f.getCaseClassName(), "(val code: Int) {")
-	g.In()
-	for _, sf := range f.subFields {
-		g.P(sf.getEnumName(), "(", sf.field.Number, "),")
-	}
-	g.P(strings.ToUpper(f.name), "_NOT_SET(0);")
-	// companion
-	g.Newline()
-	g.P("companion object {")
-	g.In()
-	g.P("fun forNumber(value: Int): ", f.getCaseClassName(), " {")
-	g.In()
-	g.P("return when (value) {")
-	g.In()
-	for _, sf := range f.subFields {
-		g.P(sf.getEnumName(), ".code -> ", sf.getEnumName())
+func enumPackagePath(g *Generator, enum *EnumDescriptor) string {
+	p := getFullPathComponents(g, enum.file, g.beanTypeName(enum))
+	if len(p) > 0 {
+		p = p[:len(p)-1]
 	}
-	notSet := fmt.Sprintf("%v_%v", strings.ToUpper(f.name), "NOT_SET")
-	g.P("else -> ", notSet)
-	g.Out()
-	g.P("}")
-	g.Out()
-	g.P("}")
-	g.Out()
-	g.P("}")
-
-	g.Out()
-	g.P("}")
-	g.Newline()
-	g.P("var ", f.name, "Case: ", f.getCaseClassName(), " = ", f.getCaseClassName(), ".", notSet)
+	return strings.Join(p, ".")
+}
+
+func enumImportPath(g *Generator, enum *EnumDescriptor) string {
+	p := getFullPathComponents(g, enum.file, g.beanTypeName(enum))
+	return strings.Join(p, ".")
 }
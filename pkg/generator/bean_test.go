@@ -0,0 +1,38 @@
+package generator_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/master-g/protoc-gen-bean/pkg/generator"
+)
+
+// TestEnumNumberMap checks the map looked up by forNumber of a sparse enum is filled without putIfAbsent,
+// which is new in Java 8 and Android API 24
+func TestEnumNumberMap(t *testing.T) {
+	values := []interface{}{"NONE", 0}
+	for i := 1; i <= 16; i++ {
+		values = append(values, fmt.Sprintf("LEVEL_%d", i), i*100)
+	}
+	tests := []struct {
+		parameter string
+		want      map[string][]string
+	}{
+		{"", map[string][]string{"Level.kt": {"if (v.code !in this) {\n                    this[v.code] = v"}}},
+		{"lang=java,javaver=7", map[string][]string{"Level.java": {"if (!BY_NUMBER.containsKey(v.code)) {"}}},
+		{"lang=java", map[string][]string{"Level.java": {"if (!BY_NUMBER.containsKey(v.code)) {"}}},
+	}
+	for _, tt := range tests {
+		resp, err := generator.Run(enumRequest(tt.parameter, values...), generator.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertContains(t, resp, tt.want)
+		for _, f := range resp.File {
+			if strings.Contains(f.GetContent(), "putIfAbsent") {
+				t.Errorf("%s: %s calls putIfAbsent", tt.parameter, f.GetName())
+			}
+		}
+	}
+}
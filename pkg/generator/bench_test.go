@@ -0,0 +1,154 @@
+package generator_test
+
+import (
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/master-g/protoc-gen-bean/pkg/generator"
+)
+
+// The size of the schema synthesized by the benchmarks, e.g.
+//
+//	go test ./pkg/generator -run '^$' -bench . -benchmem -bench.files 20 -bench.messages 100 -bench.fields 50
+var (
+	benchFiles    = flag.Int("bench.files", 10, "number of files of the benchmark schema")
+	benchMessages = flag.Int("bench.messages", 20, "number of messages per file of the benchmark schema")
+	benchFields   = flag.Int("bench.fields", 20, "number of fields per message of the benchmark schema")
+)
+
+// benchRequest returns a request generating files × messages × fields, each file importing the previous one.
+// The fields cycle through scalars, enums, messages of the same and of the imported file, lists and maps.
+func benchRequest(files, messages, fields int, parameter string) *plugin.CodeGeneratorRequest {
+	req := &plugin.CodeGeneratorRequest{Parameter: proto.String(parameter)}
+	for i := 0; i < files; i++ {
+		pkg := fmt.Sprintf("bench.f%d", i)
+		f := &descriptor.FileDescriptorProto{
+			Name:    proto.String(fmt.Sprintf("bench/f%d.proto", i)),
+			Package: proto.String(pkg),
+			Syntax:  proto.String("proto3"),
+			Options: &descriptor.FileOptions{JavaPackage: proto.String("com." + pkg)},
+			EnumType: []*descriptor.EnumDescriptorProto{{
+				Name: proto.String("Kind"),
+				Value: []*descriptor.EnumValueDescriptorProto{
+					{Name: proto.String("KIND_UNKNOWN"), Number: proto.Int32(0)},
+					{Name: proto.String("KIND_DEFAULT"), Number: proto.Int32(1)},
+				},
+			}},
+		}
+		imported := ""
+		if i > 0 {
+			imported = fmt.Sprintf(".bench.f%d", i-1)
+			f.Dependency = []string{fmt.Sprintf("bench/f%d.proto", i-1)}
+		}
+		for j := 0; j < messages; j++ {
+			msg := &descriptor.DescriptorProto{Name: proto.String(fmt.Sprintf("Message%d", j))}
+			for k := 0; k < fields; k++ {
+				field := &descriptor.FieldDescriptorProto{
+					Name:     proto.String(fmt.Sprintf("field_%d", k)),
+					JsonName: proto.String(fmt.Sprintf("field%d", k)),
+					Number:   proto.Int32(int32(k + 1)),
+					Label:    descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				}
+				other := fmt.Sprintf(".%s.Message%d", pkg, (j+k)%messages)
+				switch k % 8 {
+				case 0:
+					field.Type = descriptor.FieldDescriptorProto_TYPE_INT64.Enum()
+				case 1:
+					field.Type = descriptor.FieldDescriptorProto_TYPE_STRING.Enum()
+				case 2:
+					field.Type = descriptor.FieldDescriptorProto_TYPE_BOOL.Enum()
+				case 3:
+					field.Type = descriptor.FieldDescriptorProto_TYPE_ENUM.Enum()
+					field.TypeName = proto.String("." + pkg + ".Kind")
+				case 4:
+					field.Type = descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+					field.TypeName = proto.String(other)
+				case 5:
+					field.Type = descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+					field.TypeName = proto.String(other)
+					if imported != "" {
+						field.TypeName = proto.String(fmt.Sprintf("%s.Message%d", imported, j))
+					}
+				case 6:
+					field.Type = descriptor.FieldDescriptorProto_TYPE_DOUBLE.Enum()
+					field.Label = descriptor.FieldDescriptorProto_LABEL_REPEATED.Enum()
+				case 7:
+					entry := fmt.Sprintf("Field%dEntry", k)
+					msg.NestedType = append(msg.NestedType, &descriptor.DescriptorProto{
+						Name: proto.String(entry),
+						Field: []*descriptor.FieldDescriptorProto{
+							{
+								Name:     proto.String("key"),
+								JsonName: proto.String("key"),
+								Number:   proto.Int32(1),
+								Label:    descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+								Type:     descriptor.FieldDescriptorProto_TYPE_STRING.Enum(),
+							},
+							{
+								Name:     proto.String("value"),
+								JsonName: proto.String("value"),
+								Number:   proto.Int32(2),
+								Label:    descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+								Type:     descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+								TypeName: proto.String(other),
+							},
+						},
+						Options: &descriptor.MessageOptions{MapEntry: proto.Bool(true)},
+					})
+					field.Type = descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+					field.TypeName = proto.String(fmt.Sprintf(".%s.Message%d.%s", pkg, j, entry))
+					field.Label = descriptor.FieldDescriptorProto_LABEL_REPEATED.Enum()
+				}
+				msg.Field = append(msg.Field, field)
+			}
+			f.MessageType = append(f.MessageType, msg)
+		}
+		req.ProtoFile = append(req.ProtoFile, f)
+		req.FileToGenerate = append(req.FileToGenerate, f.GetName())
+	}
+	return req
+}
+
+// BenchmarkGenerate measures the generation of the synthesized schema with each golden parameter
+func BenchmarkGenerate(b *testing.B) {
+	for _, c := range goldenCases {
+		c := c
+		b.Run(c.name, func(b *testing.B) {
+			req := benchRequest(*benchFiles, *benchMessages, *benchFields, c.parameter)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				r := proto.Clone(req).(*plugin.CodeGeneratorRequest)
+				b.StartTimer()
+				if _, err := generator.Run(r, generator.Options{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkGenerateFields measures how the generation scales with the number of fields per message
+func BenchmarkGenerateFields(b *testing.B) {
+	for _, fields := range []int{10, 100, 1000} {
+		fields := fields
+		b.Run(fmt.Sprintf("fields=%d", fields), func(b *testing.B) {
+			req := benchRequest(1, *benchMessages, fields, "")
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				r := proto.Clone(req).(*plugin.CodeGeneratorRequest)
+				b.StartTimer()
+				if _, err := generator.Run(r, generator.Options{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,17 +1,9 @@
 package generator
 
 import (
-	"go/build"
-
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 )
 
-// generatedCodeVersion indicates a version of the generated code.
-// It is incremented whenever an incompatibility between the generated code and
-// proto package is introduced; the generated code references
-// a constant, proto.ProtoPackageIsVersionN (where N is generatedCodeVersion).
-const generatedCodeVersion = 2
-
 // A JavaImportPath is the import path of a Java package. e.g., "com.google.genproto.protobuf".
 type JavaImportPath string
 
@@ -72,14 +64,3 @@ const (
 	// tag numbers in EnumDescriptorProto
 	enumValuePath = 2 // value
 )
-
-var supportTypeAliases bool
-
-func init() {
-	for _, tag := range build.Default.ReleaseTags {
-		if tag == "go1.9" {
-			supportTypeAliases = true
-			return
-		}
-	}
-}
@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"fmt"
+
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// minCompilerVersion is the oldest protoc the generator supports, 3.12 is the first describing the proto3 optional
+// fields whose presence the converters handle, see FEATURE_PROTO3_OPTIONAL in New
+var minCompilerVersion = [3]int32{3, 12, 0}
+
+// compilerVersionString formats the version of protoc as protoc --version does, e.g. 3.21.12
+func compilerVersionString(v *plugin.Version) string {
+	s := fmt.Sprintf("%d.%d.%d", v.GetMajor(), v.GetMinor(), v.GetPatch())
+	if v.GetSuffix() != "" {
+		s += "-" + v.GetSuffix()
+	}
+	return s
+}
+
+// checkCompilerVersion warns when the request comes from a protoc older than minCompilerVersion, which strict=true
+// turns into a failure. Requests without a version, e.g. from protoc before 3.3 or other compilers, are not checked.
+func (g *Generator) checkCompilerVersion() {
+	v := g.Request.GetCompilerVersion()
+	if v == nil {
+		return
+	}
+	g.Debugf("compiler version %s", compilerVersionString(v))
+	got := [3]int32{v.GetMajor(), v.GetMinor(), v.GetPatch()}
+	for i := range got {
+		if got[i] == minCompilerVersion[i] {
+			continue
+		}
+		if got[i] < minCompilerVersion[i] {
+			min := fmt.Sprintf("%d.%d.%d", minCompilerVersion[0], minCompilerVersion[1], minCompilerVersion[2])
+			g.Warn(warnCompiler, "protoc", compilerVersionString(v), "is older than", min+", the oldest supported, upgrade protoc")
+		}
+		return
+	}
+}
@@ -0,0 +1,43 @@
+package generator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/master-g/protoc-gen-bean/pkg/generator"
+)
+
+func TestCompilerVersion(t *testing.T) {
+	version := func(major, minor, patch int32) *plugin.Version {
+		return &plugin.Version{Major: proto.Int32(major), Minor: proto.Int32(minor), Patch: proto.Int32(patch)}
+	}
+	tests := []struct {
+		version *plugin.Version
+		warned  bool
+	}{
+		{nil, false},
+		{version(3, 11, 4), true},
+		{version(3, 12, 0), false},
+		{version(4, 25, 1), false},
+	}
+	for _, tt := range tests {
+		req := fixturesRequest(t, "")
+		req.CompilerVersion = tt.version
+		var log strings.Builder
+		if _, err := generator.Run(req, generator.Options{Log: &log}); err != nil {
+			t.Fatal(err)
+		}
+		if warned := strings.Contains(log.String(), "upgrade protoc"); warned != tt.warned {
+			t.Errorf("%v: warned %v, want %v\n%s", tt.version, warned, tt.warned, log.String())
+		}
+
+		req = fixturesRequest(t, "strict=true")
+		req.CompilerVersion = tt.version
+		_, err := generator.Run(req, generator.Options{})
+		if (err != nil) != tt.warned || err != nil && !strings.Contains(err.Error(), "upgrade protoc") {
+			t.Errorf("%v: strict=true failed with %v", tt.version, err)
+		}
+	}
+}
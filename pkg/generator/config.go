@@ -0,0 +1,148 @@
+package generator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"gopkg.in/yaml.v3"
+)
+
+// MessageOverride holds the settings of a single message or enum given in the config file
+type MessageOverride struct {
+	Name string `yaml:"name"` // bean class name replacing the decorated proto name
+	Skip bool   `yaml:"skip"` // do not generate the bean and its converter
+
+	BaseClass string `yaml:"base_class"` // fully-qualified class extended by the bean
+	Default   string `yaml:"default"`    // enum constant returned for unknown numbers
+}
+
+// config is the layout of the file given by the config parameter,
+// every command line parameter may be set by its name, e.g.
+//
+//	vopkg: com.acme.vo
+//	lang: java
+//	include: [acme/billing/*.proto, acme.user.User]
+//	import_map:
+//	  acme/billing.proto: com.acme.billing.vo
+//	package_map:
+//	  acme.user: com.acme.user.vo
+//	messages:
+//	  acme.user.User:
+//	    name: Member
+//	types:
+//	  acme.user.User.created_at: java.time.Instant
+//	  google.protobuf.Timestamp: java.time.Instant
+type config struct {
+	Params     map[string]interface{}     `yaml:",inline"`
+	ImportMap  map[string]string          `yaml:"import_map"`
+	PackageMap map[string]string          `yaml:"package_map"`
+	Messages   map[string]MessageOverride `yaml:"messages"`
+	Types      map[string]string          `yaml:"types"`
+}
+
+// loadConfig reads the yaml or json config file into the parameters,
+// parameters given on the command line take precedence over the file.
+func (g *Generator) loadConfig(filename string) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		g.Error(err, "reading config", filename)
+	}
+	var c config
+	if err = yaml.Unmarshal(data, &c); err != nil {
+		g.Error(err, "parsing config", filename)
+	}
+
+	setParam := func(k, v string) {
+		if _, ok := g.Param[k]; !ok {
+			g.Param[k] = v
+		}
+	}
+	keys := make([]string, 0, len(c.Params))
+	for k := range c.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		switch value := c.Params[k].(type) {
+		case nil:
+			setParam(k, "")
+		case []interface{}:
+			items := make([]string, 0, len(value))
+			for _, item := range value {
+				items = append(items, fmt.Sprint(item))
+			}
+			setParam(k, strings.Join(items, ";"))
+		case map[string]interface{}:
+			g.Fail("unexpected mapping for", k, "in config", filename)
+		default:
+			setParam(k, fmt.Sprint(value))
+		}
+	}
+	for _, file := range sortedKeys(stringSet(c.ImportMap)) {
+		setParam("M"+file, c.ImportMap[file])
+	}
+	if len(c.PackageMap) > 0 {
+		mappings := make([]string, 0, len(c.PackageMap))
+		for _, protoPkg := range sortedKeys(stringSet(c.PackageMap)) {
+			mappings = append(mappings, protoPkg+":"+c.PackageMap[protoPkg])
+		}
+		if v, ok := g.Param["pkgmap"]; ok && v != "" {
+			// command line mappings are applied last and win
+			mappings = append(mappings, v)
+		}
+		g.Param["pkgmap"] = strings.Join(mappings, ";")
+	}
+
+	g.MessageOverrides = make(map[string]MessageOverride, len(c.Messages))
+	skipped := make([]string, 0)
+	names := make([]string, 0, len(c.Messages))
+	for name := range c.Messages {
+		names = append(names, name)
+	}
+	// .pkg.Msg and pkg.Msg name the same message, the latter wins
+	sort.Strings(names)
+	for _, name := range names {
+		o := c.Messages[name]
+		name = strings.TrimPrefix(name, ".")
+		g.MessageOverrides[name] = o
+		if o.Skip {
+			skipped = append(skipped, name)
+		}
+	}
+	if len(skipped) > 0 {
+		sort.Strings(skipped)
+		if v := g.Param["exclude"]; v != "" {
+			skipped = append(skipped, v)
+		}
+		g.Param["exclude"] = strings.Join(skipped, ";")
+	}
+
+	g.TypeOverrides = make(map[string]string, len(c.Types))
+	for _, name := range sortedKeys(stringSet(c.Types)) {
+		g.TypeOverrides[strings.TrimPrefix(name, ".")] = c.Types[name]
+	}
+}
+
+// configType returns the class the types of the config file declare the property of the field with, mapped by
+// the full name of the field, or by the full name of the message or enum of a singular field
+func (g *Generator) configType(msg *Descriptor, field *descriptor.FieldDescriptorProto) string {
+	if class, ok := g.TypeOverrides[protoFullName(msg)+"."+field.GetName()]; ok {
+		return class
+	}
+	if isRepeated(field) || field.GetTypeName() == "" {
+		return ""
+	}
+	return g.TypeOverrides[strings.TrimPrefix(field.GetTypeName(), ".")]
+}
+
+// stringSet returns the keys of the map as a set
+func stringSet(m map[string]string) map[string]bool {
+	set := make(map[string]bool, len(m))
+	for k := range m {
+		set[k] = true
+	}
+	return set
+}
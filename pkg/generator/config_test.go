@@ -0,0 +1,52 @@
+package generator_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/master-g/protoc-gen-bean/pkg/generator"
+)
+
+// writeConfig writes the config file to a temporary directory and returns the config parameter reading it
+func writeConfig(t *testing.T, config string) string {
+	name := filepath.Join(t.TempDir(), "bean.yaml")
+	if err := ioutil.WriteFile(name, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return "config=" + name
+}
+
+// TestConfigTypes checks the types of the config file declare the properties of the fields named, and of the
+// singular fields of the types named, with the class as the type option does, leaving them out of the converters
+func TestConfigTypes(t *testing.T) {
+	config := writeConfig(t, `
+types:
+  shop.order.Order.signature: com.acme.Signature
+  .shop.common.Money: java.math.BigDecimal
+`)
+	resp := runFixtures(t, "lang=java,"+config)
+	assertContains(t, resp, map[string][]string{
+		"Order.java": {
+			"private com.acme.Signature signature = null;",
+			"private java.math.BigDecimal total = null;",
+			"private java.math.BigDecimal price = null;",
+		},
+	})
+	for _, f := range resp.File {
+		if !strings.HasSuffix(f.GetName(), "ShopOrderPb2JavaBean.java") {
+			continue
+		}
+		for _, setter := range []string{"setSignature", "setTotal", "setPrice"} {
+			if strings.Contains(f.GetContent(), setter) {
+				t.Errorf("%s converts the property of %s", f.GetName(), setter)
+			}
+		}
+	}
+
+	_, err := generator.Run(fixturesRequest(t, writeConfig(t, "types:\n  shop.legacy.Stock.sku: java.util.UUID\n")), generator.Options{})
+	if err == nil || !strings.Contains(err.Error(), "required field must be converted, it cannot be skipped or retyped") {
+		t.Errorf("retyped required field: got error %v", err)
+	}
+}
@@ -0,0 +1,267 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+const (
+	// anyTypeName is the fully-qualified proto name of google.protobuf.Any
+	anyTypeName = ".google.protobuf.Any"
+	// converterSubPackage is appended to the value object package to hold converters
+	converterSubPackage = "converter"
+	// typeRegistryName is the class name of the generated Any type registry
+	typeRegistryName = "TypeRegistry"
+	// derivedSubPackage is appended to the java package of a file to hold its beans when vopkg is omitted
+	derivedSubPackage = "vo"
+	// extensionsFieldName is the bean property holding the values of proto2 extensions by their full names
+	extensionsFieldName = "extensions"
+)
+
+// isAnyField reports whether the field holds a google.protobuf.Any
+func isAnyField(field *descriptor.FieldDescriptorProto) bool {
+	return field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE && field.GetTypeName() == anyTypeName
+}
+
+// isRealOneof reports whether the field is a member of a oneof declared in the proto,
+// synthetic oneofs of proto3 optional fields are excluded.
+func isRealOneof(field *descriptor.FieldDescriptorProto) bool {
+	return field.OneofIndex != nil && !field.GetProto3Optional()
+}
+
+// javaCamelCase mirrors protoc's UnderscoresToCamelCase used by the java generator,
+// any non-alphanumeric character is dropped and the letter after it or after a digit is capitalized.
+func javaCamelCase(s string, capNext bool) string {
+	t := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isASCIILower(c):
+			if capNext {
+				c ^= ' '
+			}
+			t = append(t, c)
+			capNext = false
+		case isASCIIUpper(c):
+			if i == 0 && !capNext {
+				c ^= ' '
+			}
+			t = append(t, c)
+			capNext = false
+		case isASCIIDigit(c):
+			t = append(t, c)
+			capNext = true
+		default:
+			capNext = true
+		}
+	}
+	return string(t)
+}
+
+// javaAccessorName returns the capitalized name protobuf-java uses for the field accessors,
+// e.g. user_name -> UserName, as in getUserName()
+func javaAccessorName(field *descriptor.FieldDescriptorProto) string {
+	return javaCamelCase(field.GetName(), true)
+}
+
+// protoJavaPackage returns the java package of the classes protoc generates for the file
+func protoJavaPackage(file *FileDescriptor) string {
+	if pkg := file.GetOptions().GetJavaPackage(); pkg != "" {
+		return pkg
+	}
+	return file.GetPackage()
+}
+
+// protoJavaOuterClassName returns the outer class name protoc generates for the file
+func protoJavaOuterClassName(file *FileDescriptor) string {
+	if name := file.GetOptions().GetJavaOuterClassname(); name != "" {
+		return name
+	}
+	name := javaCamelCase(baseName(file.GetName()), true)
+	for _, msg := range file.MessageType {
+		if msg.GetName() == name {
+			return name + "OuterClass"
+		}
+	}
+	for _, enum := range file.EnumType {
+		if enum.GetName() == name {
+			return name + "OuterClass"
+		}
+	}
+	for _, svc := range file.Service {
+		if svc.GetName() == name {
+			return name + "OuterClass"
+		}
+	}
+	return name
+}
+
+// protoJavaExtensionName returns the fully-qualified name of the protobuf-java identifier of the extension,
+// e.g. com.acme.PbAcme.myExt, or com.acme.PbAcme.Outer.myExt if declared inside message Outer
+func protoJavaExtensionName(ext *ExtensionDescriptor) string {
+	name := javaCamelCase(ext.GetName(), false)
+	if ext.parent != nil {
+		return protoJavaClassName(ext.parent) + "." + name
+	}
+	p := make([]string, 0)
+	if pkg := protoJavaPackage(ext.File()); pkg != "" {
+		p = append(p, pkg)
+	}
+	p = append(p, protoJavaOuterClassName(ext.File()), name)
+	return strings.Join(p, ".")
+}
+
+// isExtendable reports whether the message declares extension ranges, its bean then holds the extension values
+func isExtendable(msg *Descriptor) bool {
+	return len(msg.ExtensionRange) > 0
+}
+
+// extensionsOf returns the extensions of the message declared in the generated files,
+// in the order of the files and of their declarations
+func (g *Generator) extensionsOf(msg *Descriptor) []*ExtensionDescriptor {
+	sl := make([]*ExtensionDescriptor, 0)
+	extendee := "." + protoFullName(msg)
+	for _, file := range g.genFiles {
+		for _, ext := range file.ext {
+			if ext.GetExtendee() == extendee {
+				sl = append(sl, ext)
+			}
+		}
+	}
+	return sl
+}
+
+// protoJavaClassName returns the fully-qualified name of the protobuf-java class of the object
+func protoJavaClassName(obj Object) string {
+	p := make([]string, 0)
+	if pkg := protoJavaPackage(obj.File()); pkg != "" {
+		p = append(p, pkg)
+	}
+	if !obj.File().GetOptions().GetJavaMultipleFiles() {
+		p = append(p, protoJavaOuterClassName(obj.File()))
+	}
+	p = append(p, obj.TypeName()...)
+	return strings.Join(p, ".")
+}
+
+// beanRootImport returns the import path of the outermost bean class containing the object
+func (g *Generator) beanRootImport(obj Object) string {
+	return g.resolve(obj).beanRoot
+}
+
+// converterPackage returns the java package of the converter of the file
+func (g *Generator) converterPackage(file *FileDescriptor) string {
+	return file.importPath.String() + "." + converterSubPackage
+}
+
+// registryPackage returns the java package of the generated type registry
+func (g *Generator) registryPackage() string {
+	return g.converterPackage(g.genFiles[0])
+}
+
+// typeRegistryRef returns the name referring to the type registry from the converter of the file
+func (g *Generator) typeRegistryRef(file *FileDescriptor) string {
+	if pkg := g.registryPackage(); pkg != g.converterPackage(file) {
+		return pkg + "." + typeRegistryName
+	}
+	return typeRegistryName
+}
+
+// converterPrefix returns the qualifier needed to call the converter of obj from the converter of file
+func (g *Generator) converterPrefix(file *FileDescriptor, obj Object) string {
+	if obj.File() == file {
+		return ""
+	}
+	return g.converterClassRef(g.converterPackage(file), obj) + "."
+}
+
+// registryDescriptors returns every message of the generated files which can be packed into an Any
+func (g *Generator) registryDescriptors() []*Descriptor {
+	sl := make([]*Descriptor, 0)
+	for _, file := range g.genFiles {
+		for _, d := range g.fileDescriptors(file) {
+			if d.GetOptions().GetMapEntry() {
+				continue
+			}
+			sl = append(sl, d)
+		}
+	}
+	return sl
+}
+
+// requiredFieldMessage returns the message of the exception thrown by converters for a missing proto2 required field
+func requiredFieldMessage(msg *Descriptor, field *descriptor.FieldDescriptorProto) string {
+	return "required field " + protoFullName(msg) + "." + field.GetName() + " is not set"
+}
+
+// protoFullName returns the proto full name of the message or enum, as used in Any type urls
+func protoFullName(d Object) string {
+	parts := d.TypeName()
+	if pkg := d.File().GetPackage(); pkg != "" {
+		parts = append([]string{pkg}, parts...)
+	}
+	return strings.Join(parts, ".")
+}
+
+// converterToBeanValue returns the expression converting a single protobuf value v of the field into its bean value
+func (g *Generator) converterToBeanValue(file *FileDescriptor, field *descriptor.FieldDescriptorProto, v string) string {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return v + ".toByteArray()"
+	case descriptor.FieldDescriptorProto_TYPE_ENUM,
+		descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptor.FieldDescriptorProto_TYPE_GROUP:
+		if isAnyField(field) {
+			return g.typeRegistryRef(file) + ".unpack(" + v + ")"
+		}
+		return g.converterPrefix(file, g.ObjectNamed(field.GetTypeName())) + "toBean(" + v + ")"
+	default:
+		return v
+	}
+}
+
+// converterToPbValue returns the expression converting a single bean value v of the field into its protobuf value
+func (g *Generator) converterToPbValue(file *FileDescriptor, field *descriptor.FieldDescriptorProto, v string) string {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return "com.google.protobuf.ByteString.copyFrom(" + v + ")"
+	case descriptor.FieldDescriptorProto_TYPE_ENUM,
+		descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptor.FieldDescriptorProto_TYPE_GROUP:
+		if isAnyField(field) {
+			return g.typeRegistryRef(file) + ".pack(" + v + ")"
+		}
+		return g.converterPrefix(file, g.ObjectNamed(field.GetTypeName())) + "toPb(" + v + ")"
+	default:
+		return v
+	}
+}
+
+// mapEntryOf returns the map entry descriptor of the field, or nil if the field is not a map
+func (g *Generator) mapEntryOf(field *descriptor.FieldDescriptorProto) *Descriptor {
+	if !isMessage(field) || isAnyField(field) {
+		return nil
+	}
+	if d, ok := g.ObjectNamed(field.GetTypeName()).(*Descriptor); ok && d.GetOptions().GetMapEntry() {
+		return d
+	}
+	return nil
+}
+
+// unusedEnumNumber returns a number which is not assigned to any value of the enum,
+// bean enums map it to their default constant
+func unusedEnumNumber(enum *EnumDescriptor) int32 {
+	var n int32 = -1
+	for _, e := range enum.Value {
+		if e.GetNumber() <= n {
+			n = e.GetNumber() - 1
+		}
+	}
+	return n
+}
+
+// oneofCaseName returns the name protobuf-java uses for the case enum of the oneof, e.g. ContactCase
+func oneofCaseName(msg *Descriptor, field *descriptor.FieldDescriptorProto) string {
+	return javaCamelCase(msg.OneofDecl[field.GetOneofIndex()].GetName(), true) + "Case"
+}
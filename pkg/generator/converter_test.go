@@ -0,0 +1,136 @@
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/master-g/protoc-gen-bean/pkg/generator"
+)
+
+// profileRequest returns a request of user/pb_profile.proto and billing/pb_profile.proto, of packages user and
+// billing, generating the files
+func profileRequest(generate ...string) *plugin.CodeGeneratorRequest {
+	profile := func(pkg string) *descriptor.FileDescriptorProto {
+		return &descriptor.FileDescriptorProto{
+			Name:        proto.String(pkg + "/pb_profile.proto"),
+			Package:     proto.String(pkg),
+			Syntax:      proto.String("proto3"),
+			Options:     &descriptor.FileOptions{JavaPackage: proto.String("com.example." + pkg)},
+			MessageType: []*descriptor.DescriptorProto{{Name: proto.String(pkg[:1] + "Profile")}},
+		}
+	}
+	return &plugin.CodeGeneratorRequest{
+		ProtoFile:      []*descriptor.FileDescriptorProto{profile("user"), profile("billing")},
+		FileToGenerate: generate,
+		Parameter:      proto.String("vopkg=com.example.vo"),
+	}
+}
+
+// TestConverterNames checks the converters named after the proto package are named after the file too,
+// the same whichever files are generated along
+func TestConverterNames(t *testing.T) {
+	tests := []struct {
+		generate []string
+		want     []string
+	}{
+		{[]string{"user/pb_profile.proto", "billing/pb_profile.proto"}, []string{
+			"com/example/vo/converter/UserProfilePb2JavaBean.kt",
+			"com/example/vo/converter/BillingProfilePb2JavaBean.kt",
+		}},
+		{[]string{"user/pb_profile.proto"}, []string{"com/example/vo/converter/UserProfilePb2JavaBean.kt"}},
+		{[]string{"billing/pb_profile.proto"}, []string{"com/example/vo/converter/BillingProfilePb2JavaBean.kt"}},
+	}
+	for _, tt := range tests {
+		resp, err := generator.Run(profileRequest(tt.generate...), generator.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := make(map[string]bool)
+		for _, f := range resp.File {
+			names[f.GetName()] = true
+		}
+		for _, name := range tt.want {
+			if !names[name] {
+				t.Errorf("%v: %s not generated", tt.generate, name)
+			}
+		}
+	}
+}
+
+// requiredRequest returns a request of counter.proto declaring Counter, a proto2 message of required scalars
+func requiredRequest(parameter string) *plugin.CodeGeneratorRequest {
+	field := func(name string, number int32, typ descriptor.FieldDescriptorProto_Type) *descriptor.FieldDescriptorProto {
+		return &descriptor.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptor.FieldDescriptorProto_LABEL_REQUIRED.Enum(),
+			Type:     typ.Enum(),
+		}
+	}
+	return &plugin.CodeGeneratorRequest{
+		ProtoFile: []*descriptor.FileDescriptorProto{{
+			Name:    proto.String("counter.proto"),
+			Package: proto.String("example.counter"),
+			Syntax:  proto.String("proto2"),
+			MessageType: []*descriptor.DescriptorProto{{
+				Name: proto.String("Counter"),
+				Field: []*descriptor.FieldDescriptorProto{
+					field("hits", 1, descriptor.FieldDescriptorProto_TYPE_INT32),
+					field("name", 2, descriptor.FieldDescriptorProto_TYPE_STRING),
+					field("digest", 3, descriptor.FieldDescriptorProto_TYPE_BYTES),
+				},
+			}},
+		}},
+		FileToGenerate: []string{"counter.proto"},
+		Parameter:      proto.String(parameter),
+	}
+}
+
+// TestRequiredScalars checks the required scalars start as null, so that toPb rejects a bean missing one
+// rather than converting a zero, whatever the scalars parameter
+func TestRequiredScalars(t *testing.T) {
+	tests := []struct {
+		parameter string
+		want      map[string][]string
+	}{
+		{"", map[string][]string{
+			"Counter.kt": {
+				"var hits: Int? = null",
+				"var name: String? = null",
+				"var digest: ByteArray? = null",
+				`append(", digest=").append(digest?.size).append(" bytes")`,
+			},
+			"ExampleCounterPb2JavaBean.kt": {
+				`requireNotNull(bean.hits) { "required field example.counter.Counter.hits is not set" }`,
+				`requireNotNull(bean.name) { "required field example.counter.Counter.name is not set" }`,
+				`requireNotNull(bean.digest) { "required field example.counter.Counter.digest is not set" }`,
+				"if (pb.hasHits()) {\n            bean.hits = pb.getHits()",
+				"bean.hits?.let { builder.setHits(it) }",
+			},
+		}},
+		{"lang=java,scalars=primitive", map[string][]string{
+			"Counter.java": {
+				"private Integer hits = null;",
+				"private String name = null;",
+				"private byte[] digest = null;",
+				`append(", digest=").append((digest == null ? null : digest.length)).append(" bytes")`,
+			},
+			"ExampleCounterPb2JavaBean.java": {
+				"if (bean.getHits() == null) {\n            throw new IllegalArgumentException(\"required field example.counter.Counter.hits is not set\");",
+				"if (bean.getName() == null) {\n            throw new IllegalArgumentException(\"required field example.counter.Counter.name is not set\");",
+				"if (bean.getDigest() == null) {\n            throw new IllegalArgumentException(\"required field example.counter.Counter.digest is not set\");",
+				"if (pb.hasHits()) {\n            bean.setHits(pb.getHits());",
+			},
+		}},
+	}
+	for _, tt := range tests {
+		resp, err := generator.Run(requiredRequest(tt.parameter), generator.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertContains(t, resp, tt.want)
+	}
+}
@@ -1,10 +1,7 @@
 package generator
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 
@@ -21,7 +18,6 @@ type Descriptor struct {
 	typename []string          // Cached typename vector.
 	index    int               // The index into the container, whether the file or another message.
 	path     string            // The SourceCodeInfo path as comma-separated integers.
-	group    bool
 }
 
 // TypeName returns the elements of the dotted type name.
@@ -73,26 +69,27 @@ func (e *EnumDescriptor) TypeName() (s []string) {
 	return s
 }
 
-// Everything but the last element of the full type name, CamelCased.
-// The values of type Foo.Bar are call Foo_value1... not Foo_Bar_value1... .
-func (e *EnumDescriptor) prefix() string {
-	if e.parent == nil {
-		// If the enum is not part of a message, the prefix is just the type name.
-		return CamelCase(*e.Name) + "_"
-	}
-	typeName := e.TypeName()
-	return CamelCaseSlice(typeName[0:len(typeName)-1]) + "_"
+// ExtensionDescriptor describes an extension. If it's at top level, its parent will be nil.
+// Otherwise it will be the descriptor of the message in which it is defined.
+type ExtensionDescriptor struct {
+	common
+	*descriptor.FieldDescriptorProto
+	parent *Descriptor // The containing message, if any.
 }
 
-// The integer value of the named constant in this enumerated type.
-func (e *EnumDescriptor) integerValueAsString(name string) string {
-	for _, c := range e.Value {
-		if c.GetName() == name {
-			return fmt.Sprint(c.GetNumber())
-		}
+// TypeName returns the elements of the dotted type name.
+// The package name is not part of this name.
+func (e *ExtensionDescriptor) TypeName() (s []string) {
+	name := e.GetName()
+	if e.parent == nil {
+		// top-level extension
+		return []string{name}
 	}
-	log.Fatal("cannot find value for enum constant")
-	return ""
+	pname := e.parent.TypeName()
+	s = make([]string, len(pname)+1)
+	copy(s, pname)
+	s[len(s)-1] = name
+	return s
 }
 
 // ImportedDescriptor describes a type that has been publicly imported from another file.
@@ -108,47 +105,20 @@ func (id *ImportedDescriptor) TypeName() []string { return id.o.TypeName() }
 // Those slices are constructed by WrapTypes.
 type FileDescriptor struct {
 	*descriptor.FileDescriptorProto
-	desc []*Descriptor         // All the messages defined in this file.
-	enum []*EnumDescriptor     // All the enums defined in this file.
-	imp  []*ImportedDescriptor // All types defined in files publicly imported by this file.
+	desc []*Descriptor          // All the messages defined in this file.
+	enum []*EnumDescriptor      // All the enums defined in this file.
+	ext  []*ExtensionDescriptor // All the extensions defined in this file.
+	imp  []*ImportedDescriptor  // All types defined in files publicly imported by this file.
 
-	// Comments, stored as a map of path (comma-separated integers) to the comment.
-	comments map[string]*descriptor.SourceCodeInfo_Location
+	// Source locations, stored as a map of path (comma-separated integers) to the location,
+	// for the comments and the positions of errors.
+	locations map[string]*descriptor.SourceCodeInfo_Location
 
-	importPath  JavaImportPath  // Import path of the beans in this file's package.
-	packageName JavaPackageName // Name of this file's Java package.
+	importPath JavaImportPath // Import path of the beans in this file's package.
 
 	proto3 bool // whether to generate proto3 code for this file
 }
 
-// VarName is the variable name we'll use in the generated code to refer
-// to the compressed bytes of this descriptor. It is not exported, so
-// it is only valid inside the generated package.
-func (d *FileDescriptor) VarName() string {
-	h := sha256.Sum256([]byte(d.GetName()))
-	return fmt.Sprintf("fileDescriptor_%s", hex.EncodeToString(h[:8]))
-}
-
-// javaPackageOption interprets the file's java_package option.
-// If there is no java_package, it returns ("", "", false).
-// If there's a simple name, it returns ("", pkg, true).
-// If the option implies an import path, it returns (impPath, pkg, true).
-func (d *FileDescriptor) javaPackageOption() (impPath JavaImportPath, pkg JavaPackageName, ok bool) {
-	opt := d.GetOptions().GetJavaPackage()
-	if opt == "" {
-		return "", "", false
-	}
-
-	impPath = JavaImportPath(opt)
-
-	opt = d.GetOptions().GetJavaOuterClassname()
-	if opt != "" {
-		pkg = JavaPackageName(opt)
-	}
-	ok = true
-	return
-}
-
 // Construct the Descriptor
 func newDescriptor(desc *descriptor.DescriptorProto, parent *Descriptor, file *FileDescriptor, index int) *Descriptor {
 	d := &Descriptor{
@@ -163,22 +133,6 @@ func newDescriptor(desc *descriptor.DescriptorProto, parent *Descriptor, file *F
 		d.path = fmt.Sprintf("%s,%d,%d", parent.path, messageMessagePath, index)
 	}
 
-	// The only way to distinguish a group from a message is whether
-	// the containing message has a TYPE_GROUP field that matches.
-	if parent != nil {
-		parts := d.TypeName()
-		if file.Package != nil {
-			parts = append([]string{*file.Package}, parts...)
-		}
-		exp := "." + strings.Join(parts, ".")
-		for _, field := range parent.Field {
-			if field.GetType() == descriptor.FieldDescriptorProto_TYPE_GROUP && field.GetTypeName() == exp {
-				d.group = true
-				break
-			}
-		}
-	}
-
 	return d
 }
 
@@ -217,6 +171,22 @@ func newEnumDescriptor(desc *descriptor.EnumDescriptorProto, parent *Descriptor,
 	return ed
 }
 
+// Return a slice of all the ExtensionDescriptors defined within this file
+func wrapExtensions(file *FileDescriptor, descs []*Descriptor) []*ExtensionDescriptor {
+	sl := make([]*ExtensionDescriptor, 0, len(file.Extension))
+	// Top-level extensions.
+	for _, field := range file.Extension {
+		sl = append(sl, &ExtensionDescriptor{common{file}, field, nil})
+	}
+	// Extensions within messages.
+	for _, nested := range descs {
+		for _, field := range nested.Extension {
+			sl = append(sl, &ExtensionDescriptor{common{file}, field, nested})
+		}
+	}
+	return sl
+}
+
 // Return a slice of all the EnumDescriptors defined within this file
 func wrapEnumDescriptors(file *FileDescriptor, descs []*Descriptor) []*EnumDescriptor {
 	sl := make([]*EnumDescriptor, 0, len(file.EnumType)+10)
@@ -233,16 +203,13 @@ func wrapEnumDescriptors(file *FileDescriptor, descs []*Descriptor) []*EnumDescr
 	return sl
 }
 
-func extractComments(file *FileDescriptor) {
-	file.comments = make(map[string]*descriptor.SourceCodeInfo_Location)
+func extractLocations(file *FileDescriptor) {
+	file.locations = make(map[string]*descriptor.SourceCodeInfo_Location)
 	for _, loc := range file.GetSourceCodeInfo().GetLocation() {
-		if loc.LeadingComments == nil && loc.TrailingComments == nil {
-			continue
-		}
 		var p []string
 		for _, n := range loc.Path {
 			p = append(p, strconv.Itoa(int(n)))
 		}
-		file.comments[strings.Join(p, ",")] = loc
+		file.locations[strings.Join(p, ",")] = loc
 	}
 }
@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// protoDescriptorsName is the name of the class embedding the descriptors of the proto files of the beans of
+// a package, with descriptors=true
+const protoDescriptorsName = "ProtoDescriptors"
+
+// descriptorChunkSize is the length of the string constants the encoded descriptors are split into,
+// below the 65535 bytes of a constant of the class files
+const descriptorChunkSize = 40000
+
+// embeddedDescriptors is the ProtoDescriptors class of a package
+type embeddedDescriptors struct {
+	Files  []string // Names of the proto files described
+	Chunks []string // The gzipped FileDescriptorSet of the files encoded in Base64, split into constants
+}
+
+// descriptorPackages returns the files generating beans by their java packages, along with the packages in order
+func (g *Generator) descriptorPackages() ([]string, map[string][]*FileDescriptor) {
+	var packages []string
+	files := make(map[string][]*FileDescriptor)
+	for _, file := range g.genFiles {
+		if len(g.fileEnums(file)) == 0 && len(g.fileDescriptors(file)) == 0 {
+			continue
+		}
+		pkg := file.importPath.String()
+		if _, ok := files[pkg]; !ok {
+			packages = append(packages, pkg)
+		}
+		files[pkg] = append(files[pkg], file)
+	}
+	return packages, files
+}
+
+// embedDescriptors returns the descriptors of the files without their source code info, as protoc-gen-go embeds them
+func (g *Generator) embedDescriptors(files []*FileDescriptor) *embeddedDescriptors {
+	set := new(descriptor.FileDescriptorSet)
+	d := &embeddedDescriptors{}
+	for _, file := range files {
+		fd := proto.Clone(file.FileDescriptorProto).(*descriptor.FileDescriptorProto)
+		fd.SourceCodeInfo = nil
+		set.File = append(set.File, fd)
+		d.Files = append(d.Files, file.GetName())
+	}
+	data, err := proto.Marshal(set)
+	if err != nil {
+		g.Error(err, "failed to marshal the descriptors of", sourceNames(files))
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, _ = w.Write(data)
+	if err = w.Close(); err != nil {
+		g.Error(err, "failed to compress the descriptors of", sourceNames(files))
+	}
+	encoded := base64.StdEncoding.EncodeToString(buf.Bytes())
+	for len(encoded) > descriptorChunkSize {
+		d.Chunks = append(d.Chunks, encoded[:descriptorChunkSize])
+		encoded = encoded[descriptorChunkSize:]
+	}
+	d.Chunks = append(d.Chunks, encoded)
+	return d
+}
+
+// generateProtoDescriptors writes the ProtoDescriptors class of every package of the beans
+func (g *Generator) generateProtoDescriptors() {
+	packages, files := g.descriptorPackages()
+	for _, pkg := range packages {
+		g.checkCanceled()
+		g.Reset()
+		if g.lang == LangJava {
+			populatePreamble(g, "package "+pkg+";", files[pkg]...)
+		} else {
+			populatePreamble(g, "package "+pkg, files[pkg]...)
+		}
+		g.beginImports(pkg, protoDescriptorsName)
+		g.render("protoDescriptors", g.embedDescriptors(files[pkg]))
+		g.printImports()
+		g.addOutputFile(g.outputFileName(files[pkg][0], pkg, protoDescriptorsName), files[pkg], nil)
+	}
+}
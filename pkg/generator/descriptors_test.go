@@ -0,0 +1,65 @@
+package generator_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func TestDescriptors(t *testing.T) {
+	tests := []struct {
+		param    string
+		name     string
+		accessor string
+	}{
+		{"lang=java,descriptors=true", "ProtoDescriptors.java",
+			"public static com.google.protobuf.DescriptorProtos.FileDescriptorSet descriptor() {"},
+		{"descriptors=true", "ProtoDescriptors.kt",
+			"fun descriptor(): com.google.protobuf.DescriptorProtos.FileDescriptorSet = set"},
+	}
+	chunk := regexp.MustCompile(`(?m)^ {8}"([A-Za-z0-9+/=]+)",$`)
+	for _, tt := range tests {
+		found := false
+		for _, f := range runFixtures(t, tt.param).File {
+			if f.GetName() != "com/example/shop/order/vo/"+tt.name {
+				continue
+			}
+			found = true
+			if !strings.Contains(f.GetContent(), tt.accessor) {
+				t.Errorf("%s: %s has no %s", tt.param, f.GetName(), tt.accessor)
+			}
+			var encoded strings.Builder
+			for _, m := range chunk.FindAllStringSubmatch(f.GetContent(), -1) {
+				encoded.WriteString(m[1])
+			}
+			data, err := base64.StdEncoding.DecodeString(encoded.String())
+			if err != nil {
+				t.Fatalf("%s: %v", tt.param, err)
+			}
+			r, err := gzip.NewReader(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("%s: %v", tt.param, err)
+			}
+			if data, err = ioutil.ReadAll(r); err != nil {
+				t.Fatalf("%s: %v", tt.param, err)
+			}
+			set := new(descriptor.FileDescriptorSet)
+			if err = proto.Unmarshal(data, set); err != nil {
+				t.Fatalf("%s: %v", tt.param, err)
+			}
+			if len(set.File) != 1 || set.File[0].GetName() != "shop/order.proto" || set.File[0].SourceCodeInfo != nil {
+				t.Errorf("%s: embedded %v", tt.param, set.File)
+			}
+		}
+		if !found {
+			t.Errorf("%s: no %s generated for shop.order", tt.param, tt.name)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package generator
+
+import "strings"
+
+// diffSyntax spells the statements of the diff methods of the beans in the target language,
+// which compare the properties of beans a and b and add the paths of the differing ones to paths
+type diffSyntax struct {
+	Terminator string                                               // Ends the statements, ; in java
+	Ref        func(f *JavaField, bean string) string               // Property of the field of the bean
+	Path       func(name, index string) string                      // Path of the field, of an element when the index is set
+	Size       func(collection string) string                       // Size of a list
+	Get        func(collection, index string) string                // Element of a list or value of a map
+	Indices    func(list string) string                             // Loop over the indices of the list into i
+	Keys       func(f *JavaField, m string) string                  // Loop over the keys of the map into key
+	Missing    func(m, key string) string                           // Condition of the key missing from the map
+	Changed    func(f *JavaField, a, b string, element bool) string // Condition of two values differing, messages excluded
+}
+
+// diffLines returns the statements of the diff method of the bean, recursing into the diff methods of the beans
+// of nested messages. Lists of different sizes and keys missing from either map are reported as changed,
+// other elements and values are compared one by one.
+func (g *Generator) diffLines(c *JavaClass, s diffSyntax) []string {
+	var lines []string
+	p := func(depth int, parts ...string) {
+		lines = append(lines, strings.Repeat(DefaultIndent, depth)+strings.Join(parts, ""))
+	}
+	compare := func(depth int, f *JavaField, a, b, path string, element bool) {
+		if f.Value.Kind == MessageKind {
+			p(depth, g.beanRef(f.Value.Object), ".diff(", path, ", ", a, ", ", b, ", paths)", s.Terminator)
+			return
+		}
+		p(depth, "if (", s.Changed(f, a, b, element), ") {")
+		p(depth+1, "paths.add(", path, ")", s.Terminator)
+		p(depth, "}")
+	}
+	for _, f := range c.Fields {
+		name := f.Proto.GetName()
+		a, b := s.Ref(f, "a"), s.Ref(f, "b")
+		switch {
+		case f.IsMap():
+			p(0, s.Keys(f, a), " {")
+			p(1, "if (", s.Missing(b, "key"), ") {")
+			p(2, "paths.add(", s.Path(name, "key"), ")", s.Terminator)
+			p(1, "} else {")
+			compare(2, f, s.Get(a, "key"), s.Get(b, "key"), s.Path(name, "key"), true)
+			p(1, "}")
+			p(0, "}")
+			p(0, s.Keys(f, b), " {")
+			p(1, "if (", s.Missing(a, "key"), ") {")
+			p(2, "paths.add(", s.Path(name, "key"), ")", s.Terminator)
+			p(1, "}")
+			p(0, "}")
+		case f.Repeated:
+			p(0, "if (", s.Size(a), " != ", s.Size(b), ") {")
+			p(1, "paths.add(", s.Path(name, ""), ")", s.Terminator)
+			p(0, "} else {")
+			p(1, s.Indices(a), " {")
+			compare(2, f, s.Get(a, "i"), s.Get(b, "i"), s.Path(name, "i"), true)
+			p(1, "}")
+			p(0, "}")
+		default:
+			compare(0, f, a, b, s.Path(name, ""), false)
+		}
+	}
+	return lines
+}
+
+// beanDiff is the diff method of a bean, with diff=true
+type beanDiff struct {
+	Class    string   // Name of the bean
+	Nullable string   // Type of the beans compared by the recursive diff method, either may be null
+	Lines    []string // Statements comparing the properties
+}
+
+// beanDiff returns the diff method of the bean, nil without diff=true
+func (g *Generator) beanDiff(c *JavaClass, nullable string, s diffSyntax) *beanDiff {
+	if !g.Diff {
+		return nil
+	}
+	return &beanDiff{Class: c.Name, Nullable: nullable, Lines: g.diffLines(c, s)}
+}
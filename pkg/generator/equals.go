@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// equalsSyntax spells the statements of the equals and hashCode methods of the beans in the target language,
+// which compare the properties of this bean to those of the other one
+type equalsSyntax struct {
+	Terminator string                                  // Ends the statements, ; in java
+	Differ     func(f *JavaField, a, b string) string  // Condition of two values of the field differing, f is nil for objects
+	Hash       func(f *JavaField, value string) string // Hash code of a value of the field, f is nil for objects
+	ListDiffer func(a, b string) string                // Condition of two lists of byte arrays differing
+	ListHash   func(list string) string                // Hash code of a list of byte arrays
+	Keys       func(f *JavaField, m string) string     // Loop over the keys of the map into key
+	Get        func(m, key string) string              // Value of the key of the map, null when missing
+	Size       func(collection string) string          // Size of a map
+	KeySet     func(m string) string                   // Keys of the map
+}
+
+// beanEquals is the equals and hashCode methods of a bean, with equals=true
+type beanEquals struct {
+	Class  string   // Name of the bean
+	Equals []string // Statements returning false when a property differs from the one of other
+	Hash   []string // Statements adding the hash codes of the properties to result
+}
+
+// beanEquals returns the equals and hashCode methods of the bean, nil without equals=true. Byte arrays are compared
+// by content, in lists and as values of maps too, the hash code of such a map covering its keys only. Other values
+// are compared by equals, which the beans of nested messages get along.
+func (g *Generator) beanEquals(c *JavaClass, s equalsSyntax) *beanEquals {
+	if !g.Equals {
+		return nil
+	}
+	e := &beanEquals{Class: c.Name}
+	p := func(depth int, parts ...string) {
+		e.Equals = append(e.Equals, strings.Repeat(DefaultIndent, depth)+strings.Join(parts, ""))
+	}
+	returnFalse := func(depth int, condition string) {
+		p(depth, "if (", condition, ") {")
+		p(depth+1, "return false", s.Terminator)
+		p(depth, "}")
+	}
+	hash := func(value string) {
+		e.Hash = append(e.Hash, "result = 31 * result + "+value+s.Terminator)
+	}
+	for _, f := range c.Fields {
+		a, b := "this."+f.Name, "other."+f.Name
+		bytes := isByteArray(f.Value)
+		switch {
+		case f.IsMap() && bytes:
+			returnFalse(0, s.Size(a)+" != "+s.Size(b))
+			p(0, s.Keys(f, a), " {")
+			returnFalse(1, s.Differ(f, s.Get(a, "key"), s.Get(b, "key")))
+			p(0, "}")
+			hash(s.KeySet(a) + ".hashCode()")
+		case f.Repeated && bytes:
+			returnFalse(0, s.ListDiffer(a, b))
+			hash(s.ListHash(a))
+		case f.Repeated:
+			returnFalse(0, s.Differ(nil, a, b))
+			hash(s.Hash(nil, a))
+		default:
+			returnFalse(0, s.Differ(f, a, b))
+			hash(s.Hash(f, a))
+		}
+	}
+	for _, o := range c.Oneofs {
+		returnFalse(0, "this."+o.Name+"Case != other."+o.Name+"Case")
+		hash(s.Hash(nil, "this."+o.Name+"Case"))
+	}
+	if c.Extendable {
+		returnFalse(0, s.Differ(nil, "this.extensions", "other.extensions"))
+		hash(s.Hash(nil, "this.extensions"))
+	}
+	return e
+}
+
+// isByteArray reports whether the values of the type are byte arrays, bytes fields without a custom type
+func isByteArray(t JavaType) bool {
+	return t.Kind == ScalarKind && t.Proto == descriptor.FieldDescriptorProto_TYPE_BYTES
+}
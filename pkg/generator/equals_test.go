@@ -0,0 +1,79 @@
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/master-g/protoc-gen-bean/pkg/generator"
+)
+
+// blobsRequest returns a request of blobs.proto declaring Blobs, a message of a list of byte arrays and a map of them
+func blobsRequest(parameter string) *plugin.CodeGeneratorRequest {
+	field := func(name string, number int32, typ descriptor.FieldDescriptorProto_Type) *descriptor.FieldDescriptorProto {
+		return &descriptor.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+	}
+	chunks := field("chunks", 1, descriptor.FieldDescriptorProto_TYPE_BYTES)
+	chunks.Label = descriptor.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	byName := field("by_name", 2, descriptor.FieldDescriptorProto_TYPE_MESSAGE)
+	byName.JsonName = proto.String("byName")
+	byName.Label = descriptor.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	byName.TypeName = proto.String(".example.blobs.Blobs.ByNameEntry")
+	return &plugin.CodeGeneratorRequest{
+		ProtoFile: []*descriptor.FileDescriptorProto{{
+			Name:    proto.String("blobs.proto"),
+			Package: proto.String("example.blobs"),
+			Syntax:  proto.String("proto3"),
+			MessageType: []*descriptor.DescriptorProto{{
+				Name:  proto.String("Blobs"),
+				Field: []*descriptor.FieldDescriptorProto{chunks, byName},
+				NestedType: []*descriptor.DescriptorProto{{
+					Name: proto.String("ByNameEntry"),
+					Field: []*descriptor.FieldDescriptorProto{
+						field("key", 1, descriptor.FieldDescriptorProto_TYPE_STRING),
+						field("value", 2, descriptor.FieldDescriptorProto_TYPE_BYTES),
+					},
+					Options: &descriptor.MessageOptions{MapEntry: proto.Bool(true)},
+				}},
+			}},
+		}},
+		FileToGenerate: []string{"blobs.proto"},
+		Parameter:      proto.String(parameter),
+	}
+}
+
+// TestEqualsByteArrays checks byte arrays in lists and maps are compared by content, the golden files cover
+// the other properties
+func TestEqualsByteArrays(t *testing.T) {
+	tests := []struct {
+		parameter string
+		want      map[string][]string
+	}{
+		{"equals=true", map[string][]string{"Blobs.kt": {
+			"if (!this.chunks.toTypedArray().contentDeepEquals(other.chunks.toTypedArray())) {",
+			"for (key in this.byName.keys) {\n            if (!this.byName[key].contentEquals(other.byName[key])) {",
+			"result = 31 * result + this.chunks.toTypedArray().contentDeepHashCode()",
+			"result = 31 * result + this.byName.keys.hashCode()",
+		}}},
+		{"lang=java,equals=true", map[string][]string{"Blobs.java": {
+			"if (!Arrays.deepEquals(this.chunks.toArray(), other.chunks.toArray())) {",
+			"for (String key : this.byName.keySet()) {\n            if (!Arrays.equals(this.byName.get(key), other.byName.get(key))) {",
+			"result = 31 * result + Arrays.deepHashCode(this.chunks.toArray());",
+			"result = 31 * result + this.byName.keySet().hashCode();",
+		}}},
+	}
+	for _, tt := range tests {
+		resp, err := generator.Run(blobsRequest(tt.parameter), generator.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertContains(t, resp, tt.want)
+	}
+}
@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SchemaError is a failure caused by the proto schema, located at the offending element of its source
+// so that schema authors find it quickly in large proto trees.
+// Run returns it, its message is the Error field of the response.
+type SchemaError struct {
+	File       string // Name of the proto file declaring the element
+	Line       int    // Line of the element, starting at 1, 0 when the request carries no source info
+	Column     int    // Column of the element, starting at 1
+	Element    string // Full name of the message, enum or field, e.g. shop.Order.items
+	Message    string // What is wrong with the element
+	Suggestion string // How to fix it, may be empty
+}
+
+// Error formats the error as protoc does, e.g. shop/order.proto:12:3: shop.Order.item: refers to undefined type .shop.Itm
+func (e *SchemaError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.location())
+	b.WriteString(": ")
+	if e.Element != "" {
+		b.WriteString(e.Element + ": ")
+	}
+	b.WriteString(e.Message)
+	if e.Suggestion != "" {
+		b.WriteString(", " + e.Suggestion)
+	}
+	return b.String()
+}
+
+// location returns the file, line and column of the element, e.g. shop/order.proto:12:3
+func (e *SchemaError) location() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d:%d", e.File, e.Line, e.Column)
+	}
+	return e.File
+}
+
+// schemaError returns the error of the element at the SourceCodeInfo path of the file
+func schemaError(file *FileDescriptor, path, element string, msgs ...string) *SchemaError {
+	e := &SchemaError{
+		File:    file.GetName(),
+		Element: element,
+		Message: strings.Join(msgs, " "),
+	}
+	if loc, ok := file.locations[path]; ok && len(loc.Span) >= 3 {
+		// spans are zero-based
+		e.Line, e.Column = int(loc.Span[0])+1, int(loc.Span[1])+1
+	}
+	return e
+}
+
+// FailAt reports a problem of the element at the SourceCodeInfo path of the file and aborts the generation
+func (g *Generator) FailAt(file *FileDescriptor, path, element string, msgs ...string) {
+	panic(failure{schemaError(file, path, element, msgs...)})
+}
+
+// failWith aborts the generation with the error
+func (g *Generator) failWith(err error) {
+	panic(failure{err})
+}
+
+// suggestType returns a suggestion fixing a reference from the file to the undefined type,
+// naming the types of the same simple name, or an empty string if there are none
+func (g *Generator) suggestType(file *FileDescriptor, typeName string) string {
+	simple := typeName[strings.LastIndexByte(typeName, '.')+1:]
+	candidates := make([]string, 0)
+	for name, obj := range g.typeNameToObject {
+		if !strings.EqualFold(name[strings.LastIndexByte(name, '.')+1:], simple) {
+			continue
+		}
+		if file != nil && obj.File() != file && !importsFile(file, obj.File().GetName()) {
+			name += " of " + obj.File().GetName() + ", which is not imported"
+		}
+		candidates = append(candidates, name)
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.Strings(candidates)
+	return "did you mean " + strings.Join(candidates, " or ") + "?"
+}
+
+// importsFile reports whether the file imports the file of the name
+func importsFile(file *FileDescriptor, name string) bool {
+	for _, dep := range file.Dependency {
+		if dep == name {
+			return true
+		}
+	}
+	return false
+}
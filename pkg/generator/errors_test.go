@@ -0,0 +1,170 @@
+package generator_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/master-g/protoc-gen-bean/pkg/generator"
+)
+
+func TestSchemaError(t *testing.T) {
+	req := fixturesRequest(t, "exclude=shop.common.Money")
+	_, err := generator.Run(req, generator.Options{})
+	var schemaErr *generator.SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("got error %v, want a schema error", err)
+	}
+	if schemaErr.File != "shop/order.proto" || schemaErr.Line != 39 || schemaErr.Element != "shop.order.Order.total" {
+		t.Errorf("got %s at %s:%d", schemaErr.Element, schemaErr.File, schemaErr.Line)
+	}
+	if !strings.HasPrefix(err.Error(), "shop/order.proto:39:3: shop.order.Order.total: ") {
+		t.Errorf("got message %q", err.Error())
+	}
+}
+
+func TestSchemaErrorSuggestion(t *testing.T) {
+	req := fixturesRequest(t, "")
+	for _, f := range req.ProtoFile {
+		if f.GetName() != "shop/order.proto" {
+			continue
+		}
+		for _, field := range f.MessageType[0].Field {
+			if field.GetName() == "total" {
+				field.TypeName = proto.String(".shop.common.money")
+			}
+		}
+	}
+	resp, err := generator.Run(req, generator.Options{})
+	var schemaErr *generator.SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("got error %v, want a schema error", err)
+	}
+	if schemaErr.Suggestion != "did you mean .shop.common.Money?" {
+		t.Errorf("got suggestion %q", schemaErr.Suggestion)
+	}
+	if resp.GetError() != err.Error() {
+		t.Errorf("got response error %q, want %q", resp.GetError(), err.Error())
+	}
+}
+
+func TestRunContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp, err := generator.RunContext(ctx, fixturesRequest(t, ""), generator.Options{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+	if resp.GetError() != err.Error() || len(resp.File) > 0 {
+		t.Errorf("got response error %q with %d files", resp.GetError(), len(resp.File))
+	}
+}
+
+// renameStock renames shop.legacy.Stock of the fixtures into shop.legacy.Money, named as shop.common.Money
+func renameStock(req *plugin.CodeGeneratorRequest) {
+	renameStockTo(req, "Money")
+}
+
+// renameStockTo renames shop.legacy.Stock of the fixtures
+func renameStockTo(req *plugin.CodeGeneratorRequest, name string) {
+	for _, f := range req.ProtoFile {
+		if f.GetName() != "shop/legacy.proto" {
+			continue
+		}
+		rename := func(s *string) {
+			*s = strings.Replace(*s, ".shop.legacy.Stock", ".shop.legacy."+name, 1)
+		}
+		f.MessageType[0].Name = proto.String(name)
+		for _, field := range f.MessageType[0].Field {
+			if field.TypeName != nil {
+				rename(field.TypeName)
+			}
+		}
+		for _, ext := range f.Extension {
+			rename(ext.Extendee)
+		}
+	}
+}
+
+func TestClassCollision(t *testing.T) {
+	// shop.legacy.Money and shop.common.Money both become com.example.vo.Money
+	req := fixturesRequest(t, "vopkg=com.example.vo")
+	renameStock(req)
+	_, err := generator.Run(req, generator.Options{})
+	var schemaErr *generator.SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("got error %v, want a schema error", err)
+	}
+	want := "shop/legacy.proto:9:1: shop.legacy.Money: bean com.example.vo.Money collides with the bean of shop.common.Money at shop/common.proto:16:1"
+	if schemaErr.Element != "shop.legacy.Money" || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("got message %q, want %q", err.Error(), want)
+	}
+	if schemaErr.Suggestion == "" {
+		t.Error("got no suggestion")
+	}
+}
+
+func TestViewCollision(t *testing.T) {
+	// the view of shop.common.Money is com.example.vo.MoneyView, as is the bean of shop.legacy.MoneyView
+	req := fixturesRequest(t, "vopkg=com.example.vo,views=true")
+	renameStockTo(req, "MoneyView")
+	_, err := generator.Run(req, generator.Options{})
+	var schemaErr *generator.SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("got error %v, want a schema error", err)
+	}
+	if !strings.Contains(err.Error(), "com.example.vo.MoneyView collides with the") {
+		t.Errorf("got message %q, want a collision of com.example.vo.MoneyView", err.Error())
+	}
+}
+
+func TestConverterCollision(t *testing.T) {
+	req := fixturesRequest(t, "vopkg=com.example.vo")
+	for _, f := range req.ProtoFile {
+		if f.GetName() == "shop/legacy.proto" {
+			f.Options.JavaOuterClassname = proto.String("CommonProto")
+		}
+	}
+	_, err := generator.Run(req, generator.Options{})
+	want := "shop/legacy.proto:3:1: shop.legacy: converter com.example.vo.converter.CommonProtoPb2JavaBean collides with the converter of shop.common at shop/common.proto:3:1"
+	if err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("got error %v, want %q", err, want)
+	}
+}
+
+func TestInvalidOutputPath(t *testing.T) {
+	for _, c := range []struct {
+		javaPackage string
+		reason      string
+	}{
+		{"/etc/shop", "the path is absolute"},
+		{"com.example..shop", "it has an empty directory name"},
+		{"com.example:shop", `":" is not allowed in file names`},
+		{"com." + strings.Repeat("x", 256), "is longer than 255 bytes"},
+	} {
+		req := fixturesRequest(t, "")
+		for _, f := range req.ProtoFile {
+			if f.GetName() == "shop/common.proto" {
+				f.Options.JavaPackage = proto.String(c.javaPackage)
+			}
+		}
+		_, err := generator.Run(req, generator.Options{})
+		if err == nil || !strings.HasPrefix(err.Error(), "shop/common.proto: invalid output file") || !strings.Contains(err.Error(), c.reason) {
+			t.Errorf("java_package %s: got error %v, want %q", c.javaPackage, err, c.reason)
+		}
+	}
+}
+
+func TestDuplicateOutputFile(t *testing.T) {
+	// the beans of shop.legacy.Money and shop.common.Money are both shop/Money.kt
+	req := fixturesRequest(t, "paths=source_relative")
+	renameStock(req)
+	_, err := generator.Run(req, generator.Options{})
+	want := "shop/legacy.proto: duplicate output file shop/Money.kt, generated from shop/common.proto and from shop/legacy.proto"
+	if err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %q", err, want)
+	}
+}
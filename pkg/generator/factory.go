@@ -0,0 +1,45 @@
+package generator
+
+import "strconv"
+
+// beanFactories are the static factories of a bean rendered by the factories templates,
+// of setting every property and from converting the protobuf message
+type beanFactories struct {
+	Class     string
+	Params    []javaProperty // Properties set by of, of is left out of beans without properties
+	Local     string         // Local variable of the bean built by of, named apart from the parameters
+	Wrap      bool           // Whether the parameters of of are wrapped one per line, the signature being wider than line_width
+	PbClass   string         // Fully-qualified protobuf-java class converted by from, empty with converters=false
+	Converter string         // Name referring to the converter of the file of the bean
+}
+
+// beanFactories returns the factories of the bean, nil unless set by the factories parameter.
+// The signature spells the declaration of of on a single line, indent is the column it is printed at.
+// slots are the parameter slots of of, which is left out with a warning when they exceed those of the jvm,
+// as for messages of hundreds of fields.
+func (g *Generator) beanFactories(c *JavaClass, params []javaProperty, slots int, signature func(params []javaProperty) string, indent int) *beanFactories {
+	if !g.Factories {
+		return nil
+	}
+	if slots > maxConstructorSlots {
+		g.Warn(warnConstructor, "the of factory of", protoFullName(c.Desc), "is left out, its",
+			strconv.Itoa(slots), "parameter slots exceed the", strconv.Itoa(maxConstructorSlots), "of the jvm")
+		params = nil
+	}
+	f := &beanFactories{Class: c.Name, Params: params, Local: "bean"}
+	for taken := true; taken; {
+		taken = false
+		for _, p := range params {
+			if p.Name == f.Local {
+				f.Local += "_"
+				taken = true
+			}
+		}
+	}
+	f.Wrap = indent+g.textWidth(signature(params)) > g.LineWidth
+	if !g.NoConverters {
+		f.PbClass = g.pbClassName(c.Desc)
+		f.Converter = g.AddImport(g.resolve(c.Desc).converter)
+	}
+	return f
+}
@@ -0,0 +1,100 @@
+package generator_test
+
+import (
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/master-g/protoc-gen-bean/pkg/generator"
+)
+
+// TestFactoriesWithoutConverters checks the from factory is left out with converters=false, the of factory kept
+func TestFactoriesWithoutConverters(t *testing.T) {
+	tests := []struct {
+		parameter string
+		bean      string
+	}{
+		{"factories=true,converters=false", "Order.kt"},
+		{"lang=java,factories=true,converters=false", "Order.java"},
+	}
+	for _, tt := range tests {
+		resp := runFixtures(t, tt.parameter)
+		assertContains(t, resp, map[string][]string{tt.bean: {" of("}})
+		for _, f := range resp.File {
+			if strings.Contains(f.GetContent(), " from(") {
+				t.Errorf("%s: %s has a from factory", tt.parameter, f.GetName())
+			}
+		}
+	}
+}
+
+// wideRequest returns a request of wide.proto declaring Wide, a message of the number of fields of the type
+func wideRequest(parameter string, fields int, typ descriptor.FieldDescriptorProto_Type) *plugin.CodeGeneratorRequest {
+	wide := &descriptor.DescriptorProto{Name: proto.String("Wide")}
+	for i := 1; i <= fields; i++ {
+		name := "f" + strconv.Itoa(i)
+		wide.Field = append(wide.Field, &descriptor.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(int32(i)),
+			Label:    descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		})
+	}
+	return &plugin.CodeGeneratorRequest{
+		ProtoFile: []*descriptor.FileDescriptorProto{{
+			Name:        proto.String("wide.proto"),
+			Package:     proto.String("example.wide"),
+			Syntax:      proto.String("proto3"),
+			MessageType: []*descriptor.DescriptorProto{wide},
+		}},
+		FileToGenerate: []string{"wide.proto"},
+		Parameter:      proto.String(parameter),
+	}
+}
+
+// TestFactoryParameterSlots checks the of factories of messages of hundreds of fields are left out with a warning
+// once their parameters exceed the 255 slots of the jvm, the Kotlin ones counting the companion
+func TestFactoryParameterSlots(t *testing.T) {
+	tests := []struct {
+		parameter string
+		fields    int
+		typ       descriptor.FieldDescriptorProto_Type
+		of        bool
+	}{
+		{"lang=java,factories=true", 255, descriptor.FieldDescriptorProto_TYPE_INT32, true},
+		{"lang=java,factories=true", 256, descriptor.FieldDescriptorProto_TYPE_INT32, false},
+		{"lang=java,factories=true", 128, descriptor.FieldDescriptorProto_TYPE_INT64, false},
+		{"lang=java,factories=true,scalars=boxed", 128, descriptor.FieldDescriptorProto_TYPE_INT64, true},
+		{"factories=true", 254, descriptor.FieldDescriptorProto_TYPE_INT32, true},
+		{"factories=true", 255, descriptor.FieldDescriptorProto_TYPE_INT32, false},
+		{"factories=true", 127, descriptor.FieldDescriptorProto_TYPE_DOUBLE, true},
+		{"factories=true", 128, descriptor.FieldDescriptorProto_TYPE_DOUBLE, false},
+	}
+	for _, tt := range tests {
+		resp, err := generator.Run(wideRequest(tt.parameter, tt.fields, tt.typ), generator.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var bean string
+		for _, f := range resp.File {
+			if strings.HasPrefix(path.Base(f.GetName()), "Wide.") {
+				bean = f.GetContent()
+			}
+		}
+		if strings.Contains(bean, " of(") != tt.of {
+			t.Errorf("%s, %d %s fields: of generated %t, want %t", tt.parameter, tt.fields, tt.typ, !tt.of, tt.of)
+		}
+		if !strings.Contains(bean, " from(") {
+			t.Errorf("%s, %d %s fields: from left out", tt.parameter, tt.fields, tt.typ)
+		}
+		_, err = generator.Run(wideRequest(tt.parameter+",strict=true", tt.fields, tt.typ), generator.Options{})
+		if warned := err != nil && strings.Contains(err.Error(), "the of factory of example.wide.Wide is left out"); warned == tt.of {
+			t.Errorf("%s, %d %s fields: warned %t, want %t", tt.parameter, tt.fields, tt.typ, warned, !tt.of)
+		}
+	}
+}
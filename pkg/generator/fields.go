@@ -0,0 +1,66 @@
+package generator
+
+import "strings"
+
+// fieldsEnumName is the name of the enum nested in the beans listing the fields of their messages, with fields_enum=true
+const fieldsEnumName = "Fields"
+
+// fieldMetadata is a constant of the Fields enum of a bean, describing a field of the message and its property
+type fieldMetadata struct {
+	Constant string // Constant of the enum, e.g. CARD_TOKEN
+	Name     string // Name of the field in the proto file, escaped for a string literal
+	JSONName string // Json name of the field, escaped for a string literal
+	Number   int32  // Number of the field
+	Class    string // Class literal of the type of the property, e.g. List.class
+	Redacted bool   // Whether toString hides the value
+	Property string // Name of the property
+	Type     string // Type of the property, set casts the value to it
+}
+
+// fieldsEnum returns the constants of the Fields enum of the bean, none without fields_enum=true or without fields.
+// The type of a property is given in the target language, its class literal is made of the type without arguments.
+func (g *Generator) fieldsEnum(c *JavaClass, fieldType func(f *JavaField) string, classLiteral func(raw string) string,
+	escape func(s string) string) []fieldMetadata {
+	if !g.FieldsEnum || len(c.Fields) == 0 {
+		return nil
+	}
+	for _, e := range c.Enums {
+		if e.Name == fieldsEnumName {
+			g.FailAt(c.Desc.File(), e.Path, protoFullName(e.Desc), "enum is named as the Fields enum of fields_enum=true")
+		}
+	}
+	for _, nested := range c.Nested {
+		if nested.Name == fieldsEnumName {
+			g.FailAt(c.Desc.File(), nested.Path, protoFullName(nested.Desc), "message is named as the Fields enum of fields_enum=true")
+		}
+	}
+	return g.fieldsMetadata(c, fieldType, classLiteral, escape)
+}
+
+// fieldsMetadata returns the metadata of the fields of the message and of their properties, in the order of the properties
+func (g *Generator) fieldsMetadata(c *JavaClass, fieldType func(f *JavaField) string, classLiteral func(raw string) string,
+	escape func(s string) string) []fieldMetadata {
+	constants := make([]fieldMetadata, len(c.Fields))
+	for i, f := range c.Fields {
+		typeName := fieldType(f)
+		raw := typeName
+		if j := strings.IndexByte(raw, '<'); j >= 0 {
+			raw = raw[:j]
+		}
+		jsonName := f.Proto.GetJsonName()
+		if jsonName == "" {
+			jsonName = defaultJSONName(f.Proto.GetName())
+		}
+		constants[i] = fieldMetadata{
+			Constant: f.CaseConstant(),
+			Name:     escape(f.Proto.GetName()),
+			JSONName: escape(jsonName),
+			Number:   f.Proto.GetNumber(),
+			Class:    classLiteral(raw),
+			Redacted: f.Redacted,
+			Property: f.Name,
+			Type:     typeName,
+		}
+	}
+	return constants
+}
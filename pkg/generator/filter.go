@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// parseGlobs splits a semicolon separated list of glob patterns
+func (g *Generator) parseGlobs(k, v string) []string {
+	globs := make([]string, 0)
+	for _, p := range strings.Split(v, ";") {
+		if p == "" {
+			continue
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			g.Error(err, "invalid pattern", p, "in", k)
+		}
+		globs = append(globs, strings.TrimPrefix(p, "."))
+	}
+	return globs
+}
+
+// matchGlobs reports whether any of the names matches any of the glob patterns
+func matchGlobs(globs []string, names ...string) bool {
+	for _, p := range globs {
+		for _, name := range names {
+			if ok, _ := path.Match(p, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rootFullName returns the proto full name of the top-level message or enum enclosing the object
+func rootFullName(obj Object) string {
+	name := obj.TypeName()[0]
+	if pkg := obj.File().GetPackage(); pkg != "" {
+		return pkg + "." + name
+	}
+	return name
+}
+
+// selectTypes decides which top-level messages and enums of the generated files are generated,
+// types matching include (all types when include is empty) are selected along with every type they depend on,
+// types matching exclude are dropped.
+func (g *Generator) selectTypes() {
+	g.excluded = make(map[string]bool)
+	if len(g.Include) == 0 && len(g.Exclude) == 0 {
+		return
+	}
+
+	genFileMap := make(map[*FileDescriptor]bool, len(g.genFiles))
+	for _, file := range g.genFiles {
+		genFileMap[file] = true
+	}
+
+	selected := make(map[string]bool)
+	queue := make([]*Descriptor, 0)
+	for _, file := range g.genFiles {
+		for _, obj := range g.rootObjects(file) {
+			name := rootFullName(obj)
+			g.excluded[name] = true
+			if len(g.Include) > 0 && !matchGlobs(g.Include, file.GetName(), name) {
+				continue
+			}
+			if matchGlobs(g.Exclude, file.GetName(), name) {
+				continue
+			}
+			selected[name] = true
+			if d, ok := obj.(*Descriptor); ok {
+				queue = append(queue, d)
+			}
+		}
+	}
+
+	// pull in the dependencies of the selected messages
+	for len(queue) > 0 {
+		msg := queue[0]
+		queue = queue[1:]
+		for _, nested := range msg.nested {
+			queue = append(queue, nested)
+		}
+		for i, field := range msg.Field {
+			if !g.isFieldConverted(field) {
+				continue
+			}
+			if !isMessage(field) &&
+				field.GetType() != descriptor.FieldDescriptorProto_TYPE_ENUM {
+				continue
+			}
+			if isAnyField(field) {
+				continue
+			}
+			obj := g.ObjectNamed(field.GetTypeName())
+			if !genFileMap[obj.File()] {
+				continue
+			}
+			name := rootFullName(obj)
+			if selected[name] {
+				continue
+			}
+			if matchGlobs(g.Exclude, obj.File().GetName(), name) {
+				err := schemaError(msg.File(), fmt.Sprintf("%s,%d,%d", msg.path, messageFieldPath, i),
+					protoFullName(msg)+"."+field.GetName(), "refers to", name, "which is excluded")
+				err.Suggestion = "skip the field or stop excluding " + name
+				g.failWith(err)
+			}
+			selected[name] = true
+			for _, root := range g.rootObjects(obj.File()) {
+				if d, ok := root.(*Descriptor); ok && rootFullName(d) == name {
+					queue = append(queue, d)
+				}
+			}
+		}
+	}
+
+	for name := range selected {
+		delete(g.excluded, name)
+	}
+}
+
+// rootObjects returns the top-level messages and enums of the file
+func (g *Generator) rootObjects(file *FileDescriptor) []Object {
+	objs := make([]Object, 0)
+	for _, e := range file.enum {
+		if e.parent == nil {
+			objs = append(objs, e)
+		}
+	}
+	for _, d := range file.desc {
+		if d.parent == nil {
+			objs = append(objs, d)
+		}
+	}
+	return objs
+}
+
+// isExcluded reports whether the object is dropped by the include and exclude parameters
+func (g *Generator) isExcluded(obj Object) bool {
+	return g.excluded[rootFullName(obj)]
+}
+
+// fileEnums returns the enums of the file, including nested ones, which are generated
+func (g *Generator) fileEnums(file *FileDescriptor) []*EnumDescriptor {
+	sl := make([]*EnumDescriptor, 0, len(file.enum))
+	for _, e := range file.enum {
+		if !g.isExcluded(e) {
+			sl = append(sl, e)
+		}
+	}
+	return sl
+}
+
+// fileDescriptors returns the messages of the file, including nested ones, which are generated
+func (g *Generator) fileDescriptors(file *FileDescriptor) []*Descriptor {
+	sl := make([]*Descriptor, 0, len(file.desc))
+	for _, d := range file.desc {
+		if !g.isExcluded(d) {
+			sl = append(sl, d)
+		}
+	}
+	return sl
+}
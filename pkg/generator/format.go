@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultLineWidth is the width the toString statements are wrapped at, unless set by line_width
+const defaultLineWidth = 100
+
+// formatSource normalizes the layout of a generated source the way the Go generator ran go/printer over its output,
+// so that the files do not fight with ktlint or google-java-format in repositories checking them in.
+// Trailing whitespace is trimmed, runs of empty lines are collapsed into one, and no empty line is kept
+// at the start or end of the file, after an opening brace or before a closing one.
+// Formatting a formatted source leaves it unchanged.
+func formatSource(content, lineEnding string) string {
+	if lineEnding == "" {
+		lineEnding = "\n"
+	}
+	lines := strings.Split(content, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			if n := len(out); n == 0 || out[n-1] == "" || strings.HasSuffix(out[n-1], "{") {
+				continue
+			}
+		} else if strings.HasPrefix(strings.TrimLeft(line, " \t"), "}") {
+			for len(out) > 0 && out[len(out)-1] == "" {
+				out = out[:len(out)-1]
+			}
+		}
+		out = append(out, line)
+	}
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	if len(out) == 0 {
+		return ""
+	}
+	return strings.Join(out, lineEnding) + lineEnding
+}
+
+// commentLines splits a proto comment into the lines of a line comment, made safe to embed in the generated source.
+// Invalid UTF-8 is replaced, carriage returns end lines as the compilers would, other control characters are dropped,
+// and the unicode escapes javac translates even within comments, e.g. \u000a ending the comment early, are escaped.
+// A line comment only ends at a line break, "*/" and "/*" are left as they are.
+func (g *Generator) commentLines(text string) []string {
+	text = strings.ToValidUTF8(text, "\uFFFD")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	for i, line := range lines {
+		line = strings.Map(func(r rune) rune {
+			if r != '\t' && unicode.IsControl(r) {
+				return -1
+			}
+			return r
+		}, line)
+		if g.lang == LangJava {
+			line = escapeUnicodeEscapes(line)
+		}
+		lines[i] = line
+	}
+	return lines
+}
+
+// escapeUnicodeEscapes doubles the backslash of each unicode escape javac would translate,
+// a backslash preceded by an odd number of backslashes does not start one
+func escapeUnicodeEscapes(s string) string {
+	if !strings.Contains(s, `\u`) {
+		return s
+	}
+	var b strings.Builder
+	backslashes := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == 'u' && backslashes%2 == 1 {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+		if c == '\\' {
+			backslashes++
+		} else {
+			backslashes = 0
+		}
+	}
+	return b.String()
+}
+
+// parseLineWidth validates the line_width parameter
+func (g *Generator) parseLineWidth(v string) int {
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		g.Fail("invalid line_width", v+", use the number of columns the generated statements are wrapped at")
+	}
+	return n
+}
+
+// wrapCalls packs the chains of calls on the receiver into statements of at most width columns at the indentation,
+// e.g. sb.append("a=").append(a).append(", b=").append(b); a chain is never split, one wider than the width
+// gets a statement of its own. Statements of bounded length also keep the expressions shallow, javac overflows
+// its stack on a concatenation of thousands of terms.
+func wrapCalls(receiver string, chains []string, end string, indent, width int) []string {
+	lines := make([]string, 0)
+	line := ""
+	for _, chain := range chains {
+		if line == "" {
+			line = receiver + "." + chain
+			continue
+		}
+		if indent+utf8.RuneCountInString(line)+1+utf8.RuneCountInString(chain)+len(end) > width {
+			lines = append(lines, line+end)
+			line = receiver + "." + chain
+			continue
+		}
+		line += "." + chain
+	}
+	if line != "" {
+		lines = append(lines, line+end)
+	}
+	return lines
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatSource(t *testing.T) {
+	tests := []struct {
+		name, content, lineEnding, want string
+	}{
+		{"empty", "\n\n", "\n", ""},
+		{"trailing whitespace", "class A {  \n    var a: Int = 0\t\n}\n", "\n", "class A {\n    var a: Int = 0\n}\n"},
+		{"empty lines", "\n\npackage a\n\n\n\nclass A\n\n\n", "\n", "package a\n\nclass A\n"},
+		{"braces", "class A {\n\n    fun a() {\n\n    }\n\n    \n}\n", "\n", "class A {\n    fun a() {\n    }\n}\n"},
+		{"nested closing braces", "a {\n    b\n\n    }\n\n}", "\n", "a {\n    b\n    }\n}\n"},
+		{"comments", "/**\n * \n * a\n */\n\n\nclass A", "\n", "/**\n *\n * a\n */\n\nclass A\n"},
+		{"crlf", "class A {\r\n\r\n    val a = 0 \r\n\r\n\r\n    val b = 1\r\n}\r\n", "\r\n", "class A {\r\n    val a = 0\r\n\r\n    val b = 1\r\n}\r\n"},
+	}
+	for _, tt := range tests {
+		got := formatSource(tt.content, tt.lineEnding)
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+		if again := formatSource(got, tt.lineEnding); again != got {
+			t.Errorf("%s: formatting again gives %q, want %q", tt.name, again, got)
+		}
+	}
+}
+
+func TestCommentLines(t *testing.T) {
+	tests := []struct {
+		name, comment string
+		lang          int
+		want          []string
+	}{
+		{"plain", " an order\n with items\n", LangJava, []string{" an order", " with items"}},
+		{"block end", " a */ b /* c\n", LangJava, []string{" a */ b /* c"}},
+		{"carriage returns", " a\r\n b\r c\n", LangKotlin, []string{" a", " b", " c"}},
+		{"control characters", " a\x00b\x1b[0m\tc\n", LangKotlin, []string{" ab[0m\tc"}},
+		{"invalid utf-8", " a\xff\xfeb\n", LangKotlin, []string{" a\uFFFDb"}},
+		{"unicode escapes", ` a\u000a b\\u000a c\\\u000d d\uu002a/`, LangJava, []string{` a\\u000a b\\u000a c\\\\u000d d\\uu002a/`}},
+		{"unicode escapes in kotlin", ` a\u000a`, LangKotlin, []string{` a\u000a`}},
+	}
+	for _, tt := range tests {
+		g := New()
+		g.lang = tt.lang
+		if got := g.commentLines(tt.comment); strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWrapCalls(t *testing.T) {
+	chains := []string{`append("a=").append(a)`, `append(", b=").append(b)`, `append(", long=").append(long.length).append(" bytes")`, `append(", c=").append(c)`}
+	tests := []struct {
+		indent, width int
+		want          []string
+	}{
+		{8, 114, []string{`sb.append("a=").append(a).append(", b=").append(b).append(", long=").append(long.length).append(" bytes");`, `sb.append(", c=").append(c);`}},
+		{8, 60, []string{`sb.append("a=").append(a).append(", b=").append(b);`, `sb.append(", long=").append(long.length).append(" bytes");`, `sb.append(", c=").append(c);`}},
+		{8, 20, []string{`sb.append("a=").append(a);`, `sb.append(", b=").append(b);`, `sb.append(", long=").append(long.length).append(" bytes");`, `sb.append(", c=").append(c);`}},
+	}
+	for _, tt := range tests {
+		got := wrapCalls("sb", chains, ";", tt.indent, tt.width)
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("width %d: got\n%s\nwant\n%s", tt.width, strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+		}
+	}
+}
@@ -0,0 +1,137 @@
+//go:build go1.18
+// +build go1.18
+
+package generator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/master-g/protoc-gen-bean/pkg/generator"
+)
+
+// fileParameters read or write files, they are dropped from the fuzzed requests
+var fileParameters = []string{"config=", "debug_dump=", "header="}
+
+// fuzzSeeds returns the fixtures request with each golden parameter, along with
+// mutations of it which used to be or could be mishandled by the generator
+func fuzzSeeds(t testing.TB) []*plugin.CodeGeneratorRequest {
+	seeds := make([]*plugin.CodeGeneratorRequest, 0)
+	for _, c := range goldenCases {
+		seeds = append(seeds, fixturesRequest(t, c.parameter))
+	}
+	mutate := func(parameter string, m func(req *plugin.CodeGeneratorRequest)) {
+		req := fixturesRequest(t, parameter)
+		m(req)
+		seeds = append(seeds, req)
+	}
+	eachField := func(req *plugin.CodeGeneratorRequest, f func(field *descriptor.FieldDescriptorProto)) {
+		var walk func(msgs []*descriptor.DescriptorProto)
+		walk = func(msgs []*descriptor.DescriptorProto) {
+			for _, msg := range msgs {
+				for _, field := range msg.Field {
+					f(field)
+				}
+				walk(msg.NestedType)
+			}
+		}
+		for _, file := range req.ProtoFile {
+			walk(file.MessageType)
+		}
+	}
+
+	// a dependency missing from the request
+	mutate("", func(req *plugin.CodeGeneratorRequest) { req.ProtoFile = req.ProtoFile[1:] })
+	// a file to generate missing from the request
+	mutate("", func(req *plugin.CodeGeneratorRequest) {
+		req.FileToGenerate = append(req.FileToGenerate, "missing.proto")
+	})
+	// references to undefined types
+	mutate("lang=java", func(req *plugin.CodeGeneratorRequest) {
+		eachField(req, func(field *descriptor.FieldDescriptorProto) {
+			if field.TypeName != nil {
+				field.TypeName = proto.String(field.GetTypeName() + "Missing")
+			}
+		})
+	})
+	// references without a type, or to a type of another kind
+	mutate("", func(req *plugin.CodeGeneratorRequest) {
+		eachField(req, func(field *descriptor.FieldDescriptorProto) {
+			switch field.GetType() {
+			case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
+				field.Type = descriptor.FieldDescriptorProto_TYPE_ENUM.Enum()
+			case descriptor.FieldDescriptorProto_TYPE_ENUM:
+				field.TypeName = nil
+			}
+		})
+	})
+	// oneof indexes out of range
+	mutate("", func(req *plugin.CodeGeneratorRequest) {
+		eachField(req, func(field *descriptor.FieldDescriptorProto) {
+			if field.OneofIndex != nil {
+				field.OneofIndex = proto.Int32(field.GetOneofIndex() + 8)
+			}
+		})
+	})
+	// deeply nested messages without names
+	mutate("lang=java", func(req *plugin.CodeGeneratorRequest) {
+		file := req.ProtoFile[len(req.ProtoFile)-1]
+		msg := &descriptor.DescriptorProto{}
+		file.MessageType = append(file.MessageType, msg)
+		for i := 0; i < 64; i++ {
+			nested := &descriptor.DescriptorProto{}
+			msg.NestedType = append(msg.NestedType, nested)
+			msg = nested
+		}
+	})
+	// an enum without values
+	mutate("", func(req *plugin.CodeGeneratorRequest) {
+		for _, file := range req.ProtoFile {
+			for _, enum := range file.EnumType {
+				enum.Value = nil
+			}
+		}
+	})
+	return seeds
+}
+
+// FuzzGenerate feeds serialized requests to the generator, which must fail with an error
+// rather than panic however malformed the descriptors are. Run it with
+//
+//	go test ./pkg/generator -run '^$' -fuzz FuzzGenerate
+func FuzzGenerate(f *testing.F) {
+	for _, req := range fuzzSeeds(f) {
+		data, err := proto.Marshal(req)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		req := new(plugin.CodeGeneratorRequest)
+		if err := proto.Unmarshal(data, req); err != nil {
+			return
+		}
+		params := make([]string, 0)
+		for _, p := range strings.Split(req.GetParameter(), ",") {
+			keep := true
+			for _, prefix := range fileParameters {
+				if strings.HasPrefix(strings.TrimSpace(p), prefix) {
+					keep = false
+				}
+			}
+			if keep {
+				params = append(params, p)
+			}
+		}
+		req.Parameter = proto.String(strings.Join(params, ","))
+
+		resp, err := generator.Run(req, generator.Options{})
+		if err != nil && resp.GetError() == "" {
+			t.Errorf("failed with %v, but the response has no error", err)
+		}
+	})
+}
@@ -2,14 +2,19 @@ package generator
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"path"
 	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
 )
 
@@ -20,9 +25,10 @@ const (
 	DefaultIndent = "    "
 )
 
+// Target languages of the generated source code
 const (
-	FlavorKotlin = iota
-	FlavorJava
+	LangKotlin = iota
+	LangJava
 )
 
 // Generator is the type whose methods generate the output, stored in the associated response structure.
@@ -34,10 +40,62 @@ type Generator struct {
 
 	Param map[string]string // Command-line parameters.
 
-	ValueObjectPackage string // Java value object output package
-	NoTime             bool   // DO NOT generate timestamp in header
+	ValueObjectPackage string   // Java value object output package
+	Timestamp          bool     // Generate timestamp in header, off by default for reproducible output
+	LineEnding         string   // Line terminator of the generated files, "\n" or "\r\n"
+	WildcardImports    int      // Number of imported classes of a package collapsed into a wildcard import, never when 0
+	LineWidth          int      // Width the statements of toString are wrapped at
+	ToString           string   // Style of the generated toString: builder, joiner, guava or none
+	FieldOrder         string   // Order of the bean properties: declaration or number
+	Clear              bool     // Generate a clear method in every bean resetting its properties to the values of a new bean
+	Mutators           bool     // Generate the add and put mutators of the list and map properties of the beans
+	Constructors       []string // Java only, constructors generated besides the no-arg one: all, required
+	Factories          bool     // Generate the static factories of and from
+	DefensiveCopies    bool     // Copy the lists, maps and byte arrays going in and out of the beans
+	Guava              bool     // Java only, hold lists, maps and messages in Guava's immutable collections and Optional
+	Nullability        string   // Java only, nullness annotations of the beans: none or jspecify
+	JavaVersion        int      // Java only, language level of the generated code: 7, 8, 11 or 17
+	Scalars            string   // Types of the singular numbers and booleans: primitive or boxed
+	Views              bool     // Generate a read-only interface of the getters of every bean, implemented by the bean
+	FieldsEnum         bool     // Generate a Fields enum in every bean with get and set accessors taking its constants
+	Visitor            bool     // Generate the FieldVisitor interface and a visit method in every bean calling it back
+	Equals             bool     // Generate equals and hashCode in every bean, comparing the values of the properties
+	Diff               bool     // Generate a static diff method in every bean returning the paths of the differing fields
+	ToMap              bool     // Generate toMap and fromMap methods in every bean holding the properties in maps
+	Base64             bool     // Generate the conversions from and to Base64 strings of the serialized messages
+	TextFormat         bool     // Generate the conversions from and to the protobuf text format
+	JSONSchema         bool     // Generate the JSON Schema of every top-level message next to its bean
+	Module             string   // Name of the java module declared by the generated module-info.java, none when empty
+	SourceLocations    bool     // Follow the comments of the classes and fields with the proto file and line they are declared at
+	Descriptors        bool     // Embed the descriptors of the proto files in a ProtoDescriptors class of every bean package
+	HeaderTemplate     string   // Custom header replacing the built-in header comment
+	Version            string   // Version of the generator, expanded in custom headers
 
-	flavor           int                        // Java or Kotlin
+	BeanPrefix string // Prepended to the names of the generated bean classes
+	BeanSuffix string // Appended to the names of the generated bean classes
+
+	Manifest bool   // Generate bean-manifest.json listing the generated files
+	Archive  string // Archive format packaging the generated files, srcjar or empty for plain files
+
+	Verbose bool // Log the details of the generation
+	Quiet   bool // Log errors only
+	Strict  bool // Fail on warnings instead of reporting them
+
+	NoBeans      bool // DO NOT generate beans, converters target existing classes of the same names
+	Bundle       bool // Kotlin only, write the beans of a proto file into a single source file
+	NoConverters bool // DO NOT generate converters and the type registry
+
+	Include        []string // Glob patterns of the proto files and messages to generate, all when empty
+	SkipDeprecated bool     // Leave deprecated fields and top-level messages and enums out of the beans and converters
+	Exclude        []string // Glob patterns of the proto files and messages to skip
+
+	ImportMap  map[string]string // Mapping from .proto file name to java package of its beans.
+	PackageMap map[string]string // Mapping from proto package to java package of its beans.
+
+	MessageOverrides map[string]MessageOverride // Per message settings from the config file, by proto full name.
+	TypeOverrides    map[string]string          // Classes of the properties from the config file, by full name of field or type.
+
+	lang             int                        // Target language, Java or Kotlin
 	allFiles         []*FileDescriptor          // All files in the tree
 	allFilesByName   map[string]*FileDescriptor // All files by input filename.
 	genFiles         []*FileDescriptor          // Those files we will generate output for.
@@ -46,6 +104,24 @@ type Generator struct {
 	indent           string
 	pathType         pathType // How to generate output filenames.
 	writeOutput      bool
+	warnings         []warning                                         // Non-fatal problems, reported at the end of the generation.
+	outputFiles      []*outputFile                                     // Generated files with their sources, for the manifest.
+	outputNames      map[string]*outputFile                            // Generated files by name, see checkDuplicate.
+	packageInfos     map[string]string                                 // Packages of the generated package-info.java by file name.
+	modulePackages   map[string]bool                                   // Packages of the generated files, exported by module-info.java.
+	stream           func(*plugin.CodeGeneratorResponse_File) error    // Receives the generated files instead of the response, see Options.Stream.
+	archive          *srcjar                                           // Archive of the generated files, for archive=srcjar.
+	excluded         map[string]bool                                   // Top-level types dropped by include and exclude, by proto full name.
+	fieldOptions     map[*descriptor.FieldDescriptorProto]fieldOptions // Field options of bean/options.proto.
+	logger           *log.Logger                                       // Diagnostics, stderr unless set by Run.
+	plugins          []Plugin                                          // Enabled plugins, see RegisterPlugin.
+	classes          map[*Descriptor]*JavaClass                        // Models of the beans, built on first use.
+	resolved         map[Object]*resolvedType                          // Names the objects are referred to by, see resolve.
+	imports          *importSet                                        // Imports of the source file being generated, see AddImport.
+	projectPackages  map[string]bool                                   // Packages of the beans and converters, see isProjectPackage.
+	templates        *template.Template                                // Templates of the target language, parsed on first use.
+	ctx              context.Context                                   // Cancels the generation, see RunContext.
+	err              error                                             // Failure of the generation, see HandleFailure.
 }
 
 // New creates a new generator and allocates the request and response protobufs.
@@ -54,21 +130,62 @@ func New() *Generator {
 	g.Buffer = new(bytes.Buffer)
 	g.Request = new(plugin.CodeGeneratorRequest)
 	g.Response = new(plugin.CodeGeneratorResponse)
+	// the converters handle the presence of proto3 optional fields,
+	// protoc refuses to run plugins which do not announce it
+	g.Response.SupportedFeatures = proto.Uint64(uint64(plugin.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL))
+	g.logger = newLogger(os.Stderr)
+	g.ctx = context.Background()
 	return g
 }
 
-// Error reports a problem, including an error, and exits the program.
+// failure is raised by Error and Fail to abort the generation
+type failure struct {
+	err error
+}
+
+// Error lets a failure raised in a template function through text/template, which turns panics into errors
+func (f failure) Error() string {
+	return f.err.Error()
+}
+
+// Error reports a problem, including an error, and aborts the generation.
 func (g *Generator) Error(err error, msgs ...string) {
-	s := strings.Join(msgs, " ") + ":" + err.Error()
-	log.Printf("%s: error:%v", GeneratorName, s)
-	os.Exit(1)
+	panic(failure{fmt.Errorf("%s: %w", strings.Join(msgs, " "), err)})
 }
 
-// Fail reports a problem and exits the program.
+// checkCanceled aborts the generation once its context is canceled or past its deadline,
+// it is called between files and top-level types so that giant requests stop early.
+func (g *Generator) checkCanceled() {
+	if err := g.ctx.Err(); err != nil {
+		g.Error(err, "generation canceled")
+	}
+}
+
+// Fail reports a problem and aborts the generation.
 func (g *Generator) Fail(msgs ...string) {
-	s := strings.Join(msgs, " ")
-	log.Printf("%v: error:%v", GeneratorName, s)
-	os.Exit(1)
+	panic(failure{errors.New(strings.Join(msgs, " "))})
+}
+
+// HandleFailure recovers from a failure raised by Error or Fail and reports it in the
+// Error field of the response, so that protoc shows the message instead of an exit status.
+// It must be deferred by the function driving the generator.
+func (g *Generator) HandleFailure() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	f, ok := r.(failure)
+	if !ok {
+		panic(r)
+	}
+	g.err = f.err
+	var schemaErr *SchemaError
+	if g.file != nil && !errors.As(f.err, &schemaErr) {
+		// the file being generated when the failure occurred, schema errors locate themselves
+		g.err = fmt.Errorf("%s: %w", g.file.GetName(), f.err)
+	}
+	g.Response.Error = proto.String(g.err.Error())
+	g.Response.File = nil
 }
 
 // CommandLineParameters breaks the comma-separated list of key=value pairs
@@ -76,6 +193,8 @@ func (g *Generator) Fail(msgs ...string) {
 // It then sets file name mappings defined by those entries.
 func (g *Generator) CommandLineParameters(parameter string) {
 	g.Param = make(map[string]string)
+	g.ImportMap = make(map[string]string)
+	g.PackageMap = make(map[string]string)
 	for _, p := range strings.Split(parameter, ",") {
 		if i := strings.Index(p, "="); i < 0 {
 			g.Param[p] = ""
@@ -84,26 +203,262 @@ func (g *Generator) CommandLineParameters(parameter string) {
 		}
 	}
 
-	for k, v := range g.Param {
+	if v, ok := g.Param["config"]; ok {
+		g.loadConfig(v)
+	}
+
+	requireVOPkg := false
+	// in the order of the names, so that the warnings and the first failure do not change between runs
+	for _, k := range sortedKeys(stringSet(g.Param)) {
+		v := g.Param[k]
+		if len(k) > 0 && k[0] == 'M' {
+			g.ImportMap[k[1:]] = paramToJavaPackage(v)
+			continue
+		}
 		switch k {
 		case "vopkg":
 			g.ValueObjectPackage = paramToJavaPackage(v)
+		case "require_vopkg":
+			requireVOPkg = g.boolParam(k, v)
+		case "timestamp":
+			g.Timestamp = g.boolParam(k, v)
 		case "notime":
-			g.NoTime = strings.EqualFold(v, "true")
+			// deprecated inverse of timestamp, an explicit timestamp always wins
+			if _, ok := g.Param["timestamp"]; !ok {
+				g.Timestamp = !g.boolParam(k, v)
+			}
+		case "lang":
+			g.lang = g.parseLang(v)
 		case "flavor":
-			if strings.EqualFold(v, "java") {
-				g.flavor = FlavorJava
-			} else {
-				g.flavor = FlavorKotlin
+			// deprecated alias of lang, an explicit lang always wins
+			if _, ok := g.Param["lang"]; !ok {
+				g.lang = g.parseLang(v)
+			}
+		case "line_ending":
+			g.LineEnding = g.parseLineEnding(v)
+		case "wildcard_imports":
+			g.WildcardImports = g.parseWildcardImports(v)
+		case "line_width":
+			g.LineWidth = g.parseLineWidth(v)
+		case "to_string":
+			g.ToString = g.parseToString(v)
+		case "field_order":
+			g.FieldOrder = g.parseFieldOrder(v)
+		case "clear":
+			g.Clear = g.boolParam(k, v)
+		case "mutators":
+			g.Mutators = g.boolParam(k, v)
+		case "constructors":
+			g.Constructors = g.parseConstructors(v)
+		case "factories":
+			g.Factories = g.boolParam(k, v)
+		case "defensive_copies":
+			g.DefensiveCopies = g.boolParam(k, v)
+		case "guava":
+			g.Guava = g.boolParam(k, v)
+		case "nullability":
+			g.Nullability = g.parseNullability(v)
+		case "javaver":
+			g.JavaVersion = g.parseJavaVersion(v)
+		case "scalars":
+			g.Scalars = g.parseScalars(v)
+		case "views":
+			g.Views = g.boolParam(k, v)
+		case "fields_enum":
+			g.FieldsEnum = g.boolParam(k, v)
+		case "visitor":
+			g.Visitor = g.boolParam(k, v)
+		case "equals":
+			g.Equals = g.boolParam(k, v)
+		case "diff":
+			g.Diff = g.boolParam(k, v)
+		case "to_map":
+			g.ToMap = g.boolParam(k, v)
+		case "base64":
+			g.Base64 = g.boolParam(k, v)
+		case "text_format":
+			g.TextFormat = g.boolParam(k, v)
+		case "json_schema":
+			g.JSONSchema = g.boolParam(k, v)
+		case "source_locations":
+			g.SourceLocations = g.boolParam(k, v)
+		case "descriptors":
+			g.Descriptors = g.boolParam(k, v)
+		case "module":
+			g.Module = g.parseModule(v)
+		case "paths":
+			switch v {
+			case "import":
+				g.pathType = pathTypeImport
+			case "source_relative":
+				g.pathType = pathTypeSourceRelative
+			default:
+				g.Fail(fmt.Sprintf(`unknown path type %q: want "import" or "source_relative"`, v))
+			}
+		case "pkgmap":
+			for _, m := range strings.Split(v, ";") {
+				if m == "" {
+					continue
+				}
+				i := strings.Index(m, ":")
+				if i < 0 {
+					g.Fail("invalid pkgmap entry", m, ", use pkgmap=proto.package:java.package;...")
+				}
+				g.PackageMap[strings.TrimPrefix(m[:i], ".")] = paramToJavaPackage(m[i+1:])
+			}
+		case "bean_prefix":
+			g.BeanPrefix = v
+		case "bean_suffix":
+			g.BeanSuffix = v
+		case "archive":
+			g.Archive = g.parseArchive(v)
+		case "manifest":
+			g.Manifest = g.boolParam(k, v)
+		case "verbose":
+			g.Verbose = g.boolParam(k, v)
+		case "quiet":
+			g.Quiet = g.boolParam(k, v)
+		case "strict":
+			g.Strict = g.boolParam(k, v)
+		case "bundle":
+			g.Bundle = g.boolParam(k, v)
+		case "beans":
+			g.NoBeans = !g.boolParam(k, v)
+		case "converters":
+			g.NoConverters = !g.boolParam(k, v)
+		case "skip_deprecated":
+			g.SkipDeprecated = g.boolParam(k, v)
+		case "include":
+			g.Include = g.parseGlobs(k, v)
+		case "exclude":
+			g.Exclude = g.parseGlobs(k, v)
+		case "plugins":
+			g.enablePlugins(v)
+		case "header":
+			data, err := ioutil.ReadFile(v)
+			if err != nil {
+				g.Error(err, "reading header template", v)
+			}
+			g.HeaderTemplate = string(data)
+		default:
+			if !isKnownParameter(k) {
+				if s := suggestParameter(k); s != "" {
+					g.Warn(warnParameter, "unknown parameter", k+", did you mean", s+"?")
+				} else {
+					g.Warn(warnParameter, "unknown parameter", k)
+				}
 			}
 		}
 	}
 
-	if g.ValueObjectPackage == "" {
+	if g.LineEnding == "" {
+		g.LineEnding = "\n"
+	}
+	if g.LineWidth == 0 {
+		g.LineWidth = defaultLineWidth
+	}
+	if g.JavaVersion == 0 {
+		g.JavaVersion = defaultJavaVersion
+	}
+	if g.ToString == "" && g.Guava {
+		g.ToString = toStringGuava
+	} else if g.ToString == "" {
+		g.ToString = toStringBuilder
+	}
+
+	if g.Bundle && g.lang != LangKotlin {
+		g.Fail("bundle=true is only supported by lang=kotlin")
+	}
+	if len(g.Constructors) > 0 && g.lang != LangJava {
+		g.Fail("constructors is only supported by lang=java")
+	}
+	if g.Guava && g.lang != LangJava {
+		g.Fail("guava=true is only supported by lang=java")
+	}
+	if g.Nullability == nullabilityJSpecify && g.lang != LangJava {
+		g.Fail("nullability=jspecify is only supported by lang=java, kotlin types tell their nullness")
+	}
+	if _, ok := g.Param["javaver"]; ok && g.lang != LangJava {
+		g.Fail("javaver is only supported by lang=java")
+	}
+	if g.JavaVersion < javaVersion8 && g.ToString == toStringJoiner {
+		g.Fail("to_string=joiner requires javaver=8 or above, java.util.StringJoiner is new in Java 8")
+	}
+	if g.JavaVersion < javaVersion8 && g.Base64 {
+		g.Fail("base64=true requires javaver=8 or above, java.util.Base64 is new in Java 8")
+	}
+	if g.JavaVersion < javaVersion8 && g.Descriptors {
+		g.Fail("descriptors=true requires javaver=8 or above, java.util.Base64 is new in Java 8")
+	}
+	if g.JavaVersion < javaVersion11 && g.Module != "" && g.lang == LangJava {
+		g.Fail("module requires javaver=11 or above, module declarations are new in Java 9")
+	}
+
+	if g.NoBeans && g.NoConverters {
+		g.Fail("nothing to generate, beans=false and converters=false")
+	}
+
+	if g.ValueObjectPackage == "" && requireVOPkg {
+		for _, k := range sortedKeys(stringSet(g.Param)) {
+			if !isKnownParameter(k) && suggestParameter(k) == "vopkg" {
+				g.Fail("invalid vo package, unknown parameter", k+", did you mean vopkg?")
+			}
+		}
 		g.Fail("invalid vo package, use --bean_out=vopkg=[package.of.vo], to set")
 	}
 }
 
+// parseLang converts the lang parameter into one of the target languages
+func (g *Generator) parseLang(v string) int {
+	switch strings.ToLower(v) {
+	case "kotlin", "kt":
+		return LangKotlin
+	case "java":
+		return LangJava
+	default:
+		g.Fail("unknown target language", v, ", use lang=kotlin or lang=java")
+		return LangKotlin
+	}
+}
+
+// parseLineEnding converts the line_ending parameter into the line terminator
+func (g *Generator) parseLineEnding(v string) string {
+	switch strings.ToLower(v) {
+	case "lf":
+		return "\n"
+	case "crlf":
+		return "\r\n"
+	default:
+		g.Fail("unknown line ending", v, ", use line_ending=lf or line_ending=crlf")
+		return "\n"
+	}
+}
+
+// fileExt returns the file extension of the target language
+func (g *Generator) fileExt() string {
+	if g.lang == LangJava {
+		return "java"
+	}
+	return "kt"
+}
+
+// outputFileName returns the name of the file holding the class of the java package,
+// with paths=source_relative it is placed next to the proto file it is generated from.
+func (g *Generator) outputFileName(file *FileDescriptor, javaPackage, className string) string {
+	if g.Module != "" {
+		g.addModulePackage(javaPackage)
+	}
+	name := className + "." + g.fileExt()
+	if g.pathType == pathTypeSourceRelative {
+		return path.Join(path.Dir(file.GetName()), name)
+	}
+	if javaPackage == "" {
+		return name
+	}
+	// joined as is, an odd java_package must not be resolved into another directory, see checkOutputPath
+	return strings.ReplaceAll(javaPackage, ".", "/") + "/" + name
+}
+
 // WrapTypes walks the incoming data, wrapping DescriptorProtos, EnumDescriptorProtos
 // and FileDescriptorProtos into file-referenced objects within the Generator.
 // It also creates the list of files to generate and so should be called before GenerateAllFiles.
@@ -112,27 +467,33 @@ func (g *Generator) WrapTypes() {
 	g.allFilesByName = make(map[string]*FileDescriptor, len(g.allFiles))
 
 	for _, f := range g.Request.ProtoFile {
+		g.checkCanceled()
 		fd := &FileDescriptor{
 			FileDescriptorProto: f,
 			proto3:              fileIsProto3(f),
 		}
 
 		// import path of this file
-		if g.ValueObjectPackage != "" {
+		if pkg, ok := g.ImportMap[f.GetName()]; ok && pkg != "" {
+			fd.importPath = JavaImportPath(pkg)
+		} else if pkg, ok := g.PackageMap[f.GetPackage()]; ok && pkg != "" {
+			fd.importPath = JavaImportPath(pkg)
+		} else if pkg := fileBeanPackage(f); pkg != "" {
+			fd.importPath = JavaImportPath(pkg)
+		} else if g.ValueObjectPackage != "" {
 			fd.importPath = JavaImportPath(g.ValueObjectPackage)
 		} else {
-			fd.importPath = JavaImportPath(strings.Join([]string{
-				g.ValueObjectPackage,
-				strings.ToLower(f.GetPackage()),
-			}, "."))
+			fd.importPath = JavaImportPath(derivedBeanPackage(fd))
 		}
+		fd.importPath = JavaImportPath(escapeJavaPackage(fd.importPath.String()))
 
 		// We must wrap the descriptors before we wrap the enums
 		fd.desc = wrapDescriptors(fd)
 		g.buildNestedDescriptors(fd.desc)
 		fd.enum = wrapEnumDescriptors(fd, fd.desc)
 		g.buildNestedEnums(fd.desc, fd.enum)
-		extractComments(fd)
+		fd.ext = wrapExtensions(fd, fd.desc)
+		extractLocations(fd)
 		g.allFiles = append(g.allFiles, fd)
 		g.allFilesByName[f.GetName()] = fd
 	}
@@ -149,6 +510,7 @@ func (g *Generator) WrapTypes() {
 		}
 		g.genFiles = append(g.genFiles, fd)
 	}
+	g.readOptions()
 }
 
 // Scan the descriptors in this file.  For each one, build the slice of nested descriptors
@@ -185,7 +547,14 @@ func (g *Generator) buildNestedEnums(descs []*Descriptor, enums []*EnumDescripto
 // Return a slice of all the types that are publicly imported into this file.
 func wrapImported(file *FileDescriptor, g *Generator) (sl []*ImportedDescriptor) {
 	for _, index := range file.PublicDependency {
+		if index < 0 || int(index) >= len(file.Dependency) {
+			g.Fail(file.GetName()+": public dependency", fmt.Sprint(index), "is not a dependency")
+		}
 		df := g.fileByName(file.Dependency[index])
+		if df == nil {
+			// absent weak dependency
+			continue
+		}
 		for _, d := range df.desc {
 			if d.GetOptions().GetMapEntry() {
 				continue
@@ -225,6 +594,34 @@ func (g *Generator) BuildTypeNameMap() {
 			g.typeNameToObject[name] = desc
 		}
 	}
+	g.validateFiles()
+	g.resolveWeakFields()
+}
+
+// resolveWeakFields leaves the weak fields out of the beans and converters unless their types are generated,
+// weak dependencies are neither required to be generated nor to be present in the request.
+func (g *Generator) resolveWeakFields() {
+	genFileMap := make(map[*FileDescriptor]bool, len(g.genFiles))
+	for _, file := range g.genFiles {
+		genFileMap[file] = true
+	}
+	for _, file := range g.genFiles {
+		for _, d := range file.desc {
+			for _, field := range d.Field {
+				if !field.GetOptions().GetWeak() {
+					continue
+				}
+				if obj, ok := g.typeNameToObject[field.GetTypeName()]; ok && genFileMap[obj.File()] {
+					continue
+				}
+				g.Debugf("%s: weak field %s.%s left out, its type %s is not generated",
+					file.GetName(), protoFullName(d), field.GetName(), strings.TrimPrefix(field.GetTypeName(), "."))
+				fo := g.fieldOptions[field]
+				fo.Skip = true
+				g.fieldOptions[field] = fo
+			}
+		}
+	}
 }
 
 // ObjectNamed given fully-qualified input type name as it appears in the input data,
@@ -232,7 +629,11 @@ func (g *Generator) BuildTypeNameMap() {
 func (g *Generator) ObjectNamed(typeName string) Object {
 	o, ok := g.typeNameToObject[typeName]
 	if !ok {
-		g.Fail("can't find object with type", typeName)
+		msg := "can't find object with type " + typeName
+		if suggestion := g.suggestType(g.file, typeName); suggestion != "" {
+			msg += ", " + suggestion
+		}
+		g.Fail(msg)
 	}
 	return o
 }
@@ -275,18 +676,32 @@ func (g *Generator) P(str ...interface{}) {
 	if !g.writeOutput {
 		return
 	}
+	start := g.Len()
 	_, _ = g.WriteString(g.indent)
 	for _, v := range str {
 		g.printAtom(v)
 	}
-	_ = g.WriteByte('\n')
+	// atoms such as comments may span several lines
+	if g.LineEnding != "\n" {
+		if line := g.Bytes()[start:]; bytes.IndexByte(line, '\n') >= 0 {
+			line = bytes.ReplaceAll(line, []byte("\n"), []byte(g.LineEnding))
+			g.Truncate(start)
+			_, _ = g.Write(line)
+		}
+	}
+	g.Newline()
 }
 
+// Newline prints an empty line without indentation.
 func (g *Generator) Newline() {
 	if !g.writeOutput {
 		return
 	}
-	_ = g.WriteByte('\n')
+	if g.LineEnding == "" {
+		_ = g.WriteByte('\n')
+		return
+	}
+	_, _ = g.WriteString(g.LineEnding)
 }
 
 // In Indents the output one tab stop.
@@ -314,26 +729,37 @@ func (g *Generator) PrintComments(path string) bool {
 	return false
 }
 
-// makeComments generates the comment string for the field, no "\n" at the end
+// makeComments generates the comment string for the field, no "\n" at the end,
+// along with the source location of the element with source_locations=true
 func (g *Generator) makeComments(path string) (string, bool) {
-	loc, ok := g.file.comments[path]
+	loc, ok := g.file.locations[path]
 	if !ok {
 		return "", false
 	}
-	if loc.LeadingComments == nil {
-		return "", false
-	}
 	w := new(bytes.Buffer)
 	nl := ""
-	for _, line := range strings.Split(strings.TrimSuffix(loc.GetLeadingComments(), "\n"), "\n") {
-		_, _ = fmt.Fprintf(w, "%s//%s", nl, line)
-		nl = "\n"
+	if loc.LeadingComments != nil {
+		for _, line := range g.commentLines(loc.GetLeadingComments()) {
+			if isDirective(line) {
+				// consumed by readOptions
+				continue
+			}
+			_, _ = fmt.Fprintf(w, "%s//%s", nl, line)
+			nl = "\n"
+		}
+	}
+	if g.SourceLocations && len(loc.Span) > 0 {
+		// spans count lines from zero
+		_, _ = fmt.Fprintf(w, "%s// source: %s:%d", nl, g.file.GetName(), loc.Span[0]+1)
+	}
+	if w.Len() == 0 {
+		return "", false
 	}
 	return w.String(), true
 }
 
 func (g *Generator) tailingComments(path string) (string, bool) {
-	loc, ok := g.file.comments[path]
+	loc, ok := g.file.locations[path]
 	if !ok {
 		return "", false
 	}
@@ -342,7 +768,7 @@ func (g *Generator) tailingComments(path string) (string, bool) {
 	}
 	w := new(bytes.Buffer)
 	nl := ""
-	for _, line := range strings.Split(strings.TrimSuffix(loc.GetTrailingComments(), "\n"), "\n") {
+	for _, line := range g.commentLines(loc.GetTrailingComments()) {
 		_, _ = fmt.Fprintf(w, "%s//%s", nl, line)
 		nl = "\n"
 	}
@@ -358,65 +784,254 @@ func (g *Generator) GenerateAllFiles() {
 	for _, file := range g.genFiles {
 		genFileMap[file] = true
 	}
+	g.selectTypes()
+	g.checkCollisions()
+	if g.Archive != "" {
+		g.openArchive()
+	}
+	for _, k := range sortedKeys(stringSet(g.Param)) {
+		g.Debugf("parameter %s=%s", k, g.Param[k])
+	}
 	for _, file := range g.allFiles {
 		g.writeOutput = genFileMap[file]
 		if !g.writeOutput {
 			continue
 		}
-		g.generateBeans(file)
+		g.checkCanceled()
+		if len(file.Service) > 0 {
+			g.logServices(file)
+		}
+		imps := g.publicImports(file)
+		if len(g.fileEnums(file)) == 0 && len(g.fileDescriptors(file)) == 0 {
+			if len(imps) == 0 || g.NoBeans {
+				// nothing selected from this file
+				g.Debugf("%s: skipped, no message or enum selected", file.GetName())
+				continue
+			}
+			// only re-exports the publicly imported beans
+			from := len(g.outputFiles)
+			g.generatePublicImports(file, imps)
+			g.logFiles(file.GetName(), from)
+			continue
+		}
+		from := len(g.outputFiles)
+		if !g.NoBeans {
+			g.generateBeans(file)
+			g.generatePublicImports(file, imps)
+		}
+		if !g.NoBeans && g.JSONSchema {
+			for _, d := range g.fileDescriptors(file) {
+				if d.parent == nil {
+					g.generateJSONSchema(file, d)
+				}
+			}
+		}
+		if !g.NoConverters {
+			g.generateConverters(file)
+		}
+		g.logFiles(file.GetName(), from)
+	}
+	if !g.NoBeans && g.Visitor {
+		from := len(g.outputFiles)
+		g.writeOutput = true
+		g.generateFieldVisitor()
+		g.logFiles(fieldVisitorName, from)
+	}
+	if g.Descriptors {
+		from := len(g.outputFiles)
+		g.writeOutput = true
+		g.generateProtoDescriptors()
+		g.logFiles(protoDescriptorsName, from)
+	}
+	if !g.NoConverters {
+		from := len(g.outputFiles)
+		g.writeOutput = true
+		g.generateTypeRegistry()
+		g.logFiles(typeRegistryName, from)
 	}
+	if g.Module != "" {
+		from := len(g.outputFiles)
+		g.writeOutput = true
+		g.generateModuleInfo()
+		g.logFiles(moduleInfoName, from)
+	}
+	if g.Strict && len(g.warnings) > 0 {
+		g.failOnWarnings()
+	}
+	if g.archive != nil {
+		g.closeArchive()
+	}
+	if g.Manifest {
+		g.generateManifest()
+	}
+	g.logWarnings()
 }
 
 // Fill the response protocol buffer with the generated output for all the descriptors in the file
 func (g *Generator) generateBeans(file *FileDescriptor) {
 	g.file = file
 
-	ext := "kt"
-	if g.flavor == FlavorJava {
-		ext = "java"
+	if g.Bundle {
+		g.generateBundle(file)
+		return
 	}
 
 	// enums
-	for _, e := range file.enum {
+	for _, e := range g.fileEnums(file) {
 		if e.parent != nil {
 			// nested enum wraps in its parent descriptor
 			continue
 		}
+		g.checkCanceled()
 		g.Reset()
 
-		if g.flavor == FlavorKotlin {
-			kotlinPopulateEnum(g, e)
+		if g.lang == LangKotlin {
+			kotlinPopulateFile(g, enumPackagePath(g, e), file, e)
 		} else {
-			javaPopulateEnum(g, e)
+			javaPopulateEnum(g, g.javaEnum(e))
 		}
 
-		fullPath := getFullPathComponents(g, file, e.TypeName())
-		fullPath = append(fullPath[:len(fullPath)-1], fmt.Sprintf("%s.%s", e.GetName(), ext))
-		g.Response.File = append(g.Response.File, &plugin.CodeGeneratorResponse_File{
-			Name:    proto.String(path.Join(fullPath...)),
-			Content: proto.String(g.String()),
-		})
+		g.addOutputFile(g.outputFileName(file, enumPackagePath(g, e), g.beanName(e)), []*FileDescriptor{file}, []Object{e})
+		g.generatePackageInfo(file, enumPackagePath(g, e))
 	}
 
 	// descriptors
-	for _, d := range file.desc {
+	for _, d := range g.fileDescriptors(file) {
 		if d.parent != nil {
 			// nested message wraps in its parent descriptor
 			continue
 		}
+		g.checkCanceled()
 		g.Reset()
 
-		if g.flavor == FlavorKotlin {
-			kotlinPopulateDescriptor(g, d)
+		if g.lang == LangKotlin {
+			kotlinPopulateFile(g, descriptorPackagePath(g, d), file, d)
 		} else {
-			javaPopulateDescriptor(g, d)
+			javaPopulateDescriptor(g, g.javaClass(d))
+		}
+
+		g.addOutputFile(g.outputFileName(file, descriptorPackagePath(g, d), g.beanName(d)), []*FileDescriptor{file}, []Object{d})
+		g.generatePackageInfo(file, descriptorPackagePath(g, d))
+
+		if c := g.javaClass(d); c.View != "" && g.lang == LangJava {
+			// kotlin declares the view in the file of the bean
+			g.Reset()
+			javaPopulateView(g, c)
+			g.addOutputFile(g.outputFileName(file, descriptorPackagePath(g, d), c.View), []*FileDescriptor{file}, []Object{d})
+		}
+	}
+}
+
+// generateBundle writes every top-level bean of the file into a single Kotlin source file
+func (g *Generator) generateBundle(file *FileDescriptor) {
+	objs := make([]Object, 0)
+	for _, e := range g.fileEnums(file) {
+		if e.parent == nil {
+			objs = append(objs, e)
+		}
+	}
+	for _, d := range g.fileDescriptors(file) {
+		if d.parent == nil {
+			objs = append(objs, d)
 		}
+	}
+	if len(objs) == 0 {
+		return
+	}
+
+	// the beans of a file share the same package
+	var thisPackage string
+	switch o := objs[0].(type) {
+	case *EnumDescriptor:
+		thisPackage = enumPackagePath(g, o)
+	case *Descriptor:
+		thisPackage = descriptorPackagePath(g, o)
+	}
+
+	g.Reset()
+	kotlinPopulateFile(g, thisPackage, file, objs...)
+	g.addOutputFile(g.outputFileName(file, thisPackage, bundleName(file)), []*FileDescriptor{file}, objs)
+}
+
+// publicImports returns the top-level beans publicly imported by the file which live in another package
+func (g *Generator) publicImports(file *FileDescriptor) []*ImportedDescriptor {
+	sl := make([]*ImportedDescriptor, 0, len(file.imp))
+	for _, imp := range file.imp {
+		obj := imp.o
+		if len(obj.TypeName()) > 1 || g.isExcluded(obj) || obj.File().importPath == file.importPath {
+			continue
+		}
+		sl = append(sl, imp)
+	}
+	return sl
+}
+
+// generatePublicImports re-exports the publicly imported beans in the bean package of the file,
+// mirroring the aliases of protoc-gen-go, so that moving messages behind an import public keeps the bean names valid.
+// Kotlin declares type aliases, java extends the imported bean classes, which is not possible for enums.
+func (g *Generator) generatePublicImports(file *FileDescriptor, imps []*ImportedDescriptor) {
+	if len(imps) == 0 {
+		return
+	}
+	g.file = file
+	thisPackage := file.importPath.String()
+
+	if g.lang == LangKotlin {
+		objs := make([]Object, 0, len(imps))
+		for _, imp := range imps {
+			objs = append(objs, imp.o)
+		}
+		g.Reset()
+		kotlinPopulateTypeAliases(g, thisPackage, file, imps)
+		g.addOutputFile(g.outputFileName(file, thisPackage, aliasesName(file)), []*FileDescriptor{file}, objs)
+		return
+	}
+
+	for _, imp := range imps {
+		if _, ok := imp.o.(*EnumDescriptor); ok {
+			g.Infof("%s: enum %s is not re-exported, java enums cannot be extended", file.GetName(), protoFullName(imp.o))
+			continue
+		}
+		g.Reset()
+		javaPopulatePublicImport(g, thisPackage, file, imp)
+		g.addOutputFile(g.outputFileName(file, thisPackage, g.beanName(imp.o)), []*FileDescriptor{file}, []Object{imp.o})
+	}
+}
+
+// Fill the response protocol buffer with the converter between protobuf-java classes and beans of the file
+func (g *Generator) generateConverters(file *FileDescriptor) {
+	g.file = file
+	g.Reset()
+	c := g.buildConverter(file)
+	if g.lang == LangJava {
+		javaPopulateConverter(g, c)
+	} else {
+		kotlinPopulateConverter(g, c)
+	}
+
+	types := make([]Object, 0, len(c.Enums)+len(c.Classes))
+	for _, e := range c.Enums {
+		types = append(types, e.Desc)
+	}
+	for _, class := range c.Classes {
+		types = append(types, class.Desc)
+	}
+	g.addOutputFile(g.outputFileName(file, c.Package, c.Name), []*FileDescriptor{file}, types)
+}
+
+// Fill the response protocol buffer with the registry resolving Any messages into beans
+func (g *Generator) generateTypeRegistry() {
+	g.Reset()
+
+	if g.lang == LangJava {
+		javaPopulateTypeRegistry(g)
+	} else {
+		kotlinPopulateTypeRegistry(g)
+	}
 
-		fullPath := getFullPathComponents(g, file, d.TypeName())
-		fullPath = append(fullPath[:len(fullPath)-1], fmt.Sprintf("%s.%s", d.GetName(), ext))
-		g.Response.File = append(g.Response.File, &plugin.CodeGeneratorResponse_File{
-			Name:    proto.String(path.Join(fullPath...)),
-			Content: proto.String(g.String()),
-		})
+	types := make([]Object, 0)
+	for _, d := range g.registryDescriptors() {
+		types = append(types, d)
 	}
+	g.addOutputFile(g.outputFileName(g.genFiles[0], g.registryPackage(), typeRegistryName), g.genFiles, types)
 }
@@ -0,0 +1,300 @@
+package generator_test
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/master-g/protoc-gen-bean/pkg/generator"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the generated output")
+
+// fixturesSet is compiled from the protos in testdata/protos, after changing them run in this directory
+//
+//	protoc -I testdata/protos --include_imports --include_source_info --descriptor_set_out=testdata/fixtures.pb shop/*.proto
+const fixturesSet = "testdata/fixtures.pb"
+
+// goldenCases are the parameters the fixtures are generated with, into testdata/golden/<name>.
+// The kotlin and java cases hold the full output, the feature cases after them only the files
+// which differ from the output of the case of their language, see goldenBase.
+var goldenCases = []struct {
+	name      string
+	parameter string
+}{
+	{"kotlin", ""},
+	{"java", "lang=java"},
+	{"kotlin_bundle", "bundle=true"},
+	{"kotlin_clear", "clear=true"},
+	{"java_clear", "lang=java,clear=true"},
+	{"kotlin_mutators", "mutators=true"},
+	{"java_mutators", "lang=java,mutators=true"},
+	{"kotlin_to_string_joiner", "to_string=joiner"},
+	{"java_to_string_joiner", "lang=java,to_string=joiner"},
+	{"kotlin_to_string_guava", "to_string=guava"},
+	{"java_to_string_guava", "lang=java,to_string=guava"},
+	{"java_constructors", "lang=java,constructors=all;required"},
+	{"kotlin_factories", "factories=true"},
+	{"java_factories", "lang=java,factories=true"},
+	{"kotlin_defensive_copies", "defensive_copies=true"},
+	{"java_defensive_copies", "lang=java,defensive_copies=true"},
+	{"java_guava", "lang=java,guava=true,mutators=true"},
+	{"java_jspecify", "lang=java,nullability=jspecify"},
+	{"java_javaver17", "lang=java,javaver=17"},
+	{"kotlin_boxed", "scalars=boxed"},
+	{"java_boxed", "lang=java,scalars=boxed"},
+	{"kotlin_views", "views=true"},
+	{"java_views", "lang=java,views=true"},
+	{"kotlin_fields_enum", "fields_enum=true"},
+	{"java_fields_enum", "lang=java,fields_enum=true"},
+	{"kotlin_visitor", "visitor=true"},
+	{"java_visitor", "lang=java,visitor=true"},
+	{"kotlin_equals", "equals=true"},
+	{"java_equals", "lang=java,equals=true"},
+	{"kotlin_diff", "diff=true"},
+	{"java_diff", "lang=java,diff=true"},
+	{"kotlin_to_map", "to_map=true"},
+	{"java_to_map", "lang=java,to_map=true"},
+	{"kotlin_base64", "base64=true"},
+	{"java_base64", "lang=java,base64=true"},
+	{"kotlin_text_format", "text_format=true"},
+	{"java_text_format", "lang=java,text_format=true"},
+	{"kotlin_source_locations", "source_locations=true"},
+	{"java_source_locations", "lang=java,source_locations=true"},
+	{"kotlin_module", "module=com.example.shop,converters=false"},
+	{"java_module", "lang=java,javaver=11,module=com.example.shop,nullability=jspecify"},
+}
+
+// fixturesRequest returns a request generating every fixture with the parameter
+func fixturesRequest(t testing.TB, parameter string) *plugin.CodeGeneratorRequest {
+	data, err := ioutil.ReadFile(fixturesSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := new(descriptor.FileDescriptorSet)
+	if err = proto.Unmarshal(data, set); err != nil {
+		t.Fatal(err)
+	}
+	req := &plugin.CodeGeneratorRequest{
+		ProtoFile: set.File,
+		Parameter: proto.String(parameter),
+	}
+	for _, f := range set.File {
+		if !strings.HasPrefix(f.GetName(), "google/protobuf/") {
+			req.FileToGenerate = append(req.FileToGenerate, f.GetName())
+		}
+	}
+	return req
+}
+
+// runFixtures generates every fixture with the parameter, failing the test if the generation fails
+func runFixtures(t testing.TB, parameter string) *plugin.CodeGeneratorResponse {
+	t.Helper()
+	resp, err := generator.Run(fixturesRequest(t, parameter), generator.Options{})
+	if err != nil {
+		t.Fatalf("%s: %v", parameter, err)
+	}
+	return resp
+}
+
+// assertContains checks the files of the response hold the literals wanted of them, a file being named by its path,
+// or by its base name if the name has no slash
+func assertContains(t testing.TB, resp *plugin.CodeGeneratorResponse, want map[string][]string) {
+	t.Helper()
+	generated := make(map[string]bool)
+	for _, f := range resp.File {
+		for name, literals := range want {
+			if name != f.GetName() && (strings.Contains(name, "/") || name != path.Base(f.GetName())) {
+				continue
+			}
+			generated[name] = true
+			for _, literal := range literals {
+				if !strings.Contains(f.GetContent(), literal) {
+					t.Errorf("%s has no %s", f.GetName(), literal)
+				}
+			}
+		}
+	}
+	for name := range want {
+		if !generated[name] {
+			t.Errorf("no %s generated", name)
+		}
+	}
+}
+
+// goldenBase returns the name of the case holding the files the feature case leaves unchanged,
+// the case of the language the feature case is named after, or an empty string for the base cases
+func goldenBase(name string) string {
+	if i := strings.IndexByte(name, '_'); i >= 0 {
+		return name[:i]
+	}
+	return ""
+}
+
+// TestGolden compares the output generated from the fixtures with the golden files,
+// run go test ./pkg/generator -update to accept intended changes.
+func TestGolden(t *testing.T) {
+	for _, c := range goldenCases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			resp := runFixtures(t, c.parameter)
+			dir := filepath.Join("testdata", "golden", c.name)
+			base := ""
+			if b := goldenBase(c.name); b != "" {
+				base = filepath.Join("testdata", "golden", b)
+			}
+			if *update {
+				writeGolden(t, dir, base, resp)
+				return
+			}
+
+			generated := make(map[string]bool)
+			for _, f := range resp.File {
+				name := filepath.Join(dir, filepath.FromSlash(f.GetName()))
+				generated[name] = true
+				want, err := ioutil.ReadFile(name)
+				if err == nil && base != "" {
+					if same, _ := ioutil.ReadFile(filepath.Join(base, filepath.FromSlash(f.GetName()))); string(same) == string(want) {
+						t.Errorf("%s: same as the file of %s, run with -update to remove it", name, base)
+					}
+				} else if os.IsNotExist(err) && base != "" {
+					name = filepath.Join(base, filepath.FromSlash(f.GetName()))
+					want, err = ioutil.ReadFile(name)
+				}
+				if err != nil {
+					t.Errorf("%s: missing golden file, run with -update to create it", name)
+					continue
+				}
+				if diff := firstDifference(string(want), f.GetContent()); diff != "" {
+					t.Errorf("%s: %s (%s), run with -update if the change is intended", name, diff, c.name)
+				}
+			}
+			err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+				if path == dir && os.IsNotExist(err) && base != "" {
+					// every file of the feature case is the same as the file of its base case
+					return nil
+				}
+				if err == nil && !info.IsDir() && !generated[path] {
+					t.Errorf("%s: no longer generated, run with -update to remove it", path)
+				}
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// writeGolden replaces the golden files in the directory with the files of the response,
+// leaving out those holding the same content as the file of the base directory if one is given
+func writeGolden(t *testing.T, dir, base string, resp *plugin.CodeGeneratorResponse) {
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range resp.File {
+		if base != "" {
+			if same, err := ioutil.ReadFile(filepath.Join(base, filepath.FromSlash(f.GetName()))); err == nil && string(same) == f.GetContent() {
+				continue
+			}
+		}
+		name := filepath.Join(dir, filepath.FromSlash(f.GetName()))
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(name, []byte(f.GetContent()), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// firstDifference describes the first line which differs between the golden and the generated content,
+// or returns an empty string if they are the same
+func firstDifference(want, got string) string {
+	if want == got {
+		return ""
+	}
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	for i := 0; i < len(wantLines) && i < len(gotLines); i++ {
+		if wantLines[i] != gotLines[i] {
+			return fmt.Sprintf("line %d is %q, want %q", i+1, gotLines[i], wantLines[i])
+		}
+	}
+	return fmt.Sprintf("has %d lines, want %d", len(gotLines), len(wantLines))
+}
+
+func TestRunStream(t *testing.T) {
+	want := runFixtures(t, "lang=java")
+	streamed := make([]*plugin.CodeGeneratorResponse_File, 0)
+	resp, err := generator.Run(fixturesRequest(t, "lang=java"), generator.Options{
+		Stream: func(f *plugin.CodeGeneratorResponse_File) error {
+			streamed = append(streamed, f)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.File) > 0 {
+		t.Errorf("got %d files in the response, want them streamed", len(resp.File))
+	}
+	if len(streamed) != len(want.File) {
+		t.Fatalf("got %d files streamed, want %d", len(streamed), len(want.File))
+	}
+	for i, f := range streamed {
+		if !proto.Equal(f, want.File[i]) {
+			t.Errorf("streamed %s, want %s", f.GetName(), want.File[i].GetName())
+		}
+	}
+}
+
+// TestDeterministic runs the generation repeatedly, the output and the diagnostics must not change between runs
+func TestDeterministic(t *testing.T) {
+	const parameter = "lang=java,manifest=true,verbose,colour=true,zeta,alpha=1,bean_sufix=Vo,Mfoo.proto=com.foo,Mbar.proto=com.bar"
+	var want *plugin.CodeGeneratorResponse
+	var wantLog string
+	for i := 0; i < 10; i++ {
+		var log strings.Builder
+		resp, err := generator.Run(fixturesRequest(t, parameter), generator.Options{Log: &log})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			want, wantLog = resp, log.String()
+			continue
+		}
+		if !proto.Equal(resp, want) {
+			t.Fatalf("run %d generated a different response", i+1)
+		}
+		if log.String() != wantLog {
+			t.Fatalf("run %d logged\n%s\nwant\n%s", i+1, log.String(), wantLog)
+		}
+	}
+}
+
+// TestNoSamePackageImports checks that no generated file imports a class of its own package
+func TestNoSamePackageImports(t *testing.T) {
+	for _, c := range goldenCases {
+		for _, f := range runFixtures(t, c.parameter).File {
+			pkg := ""
+			for _, line := range strings.Split(f.GetContent(), "\n") {
+				line = strings.TrimSuffix(line, ";")
+				if strings.HasPrefix(line, "package ") {
+					pkg = strings.TrimPrefix(line, "package ")
+				}
+				if class := strings.TrimPrefix(line, "import "); class != line && class[:strings.LastIndexByte(class, '.')] == pkg {
+					t.Errorf("%s: %s imports %s of its own package", c.name, f.GetName(), class)
+				}
+			}
+		}
+	}
+}
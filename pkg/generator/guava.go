@@ -0,0 +1,14 @@
+package generator
+
+// The Guava classes of the beans generated with guava=true
+const (
+	guavaImmutableList = "com.google.common.collect.ImmutableList"
+	guavaImmutableMap  = "com.google.common.collect.ImmutableMap"
+	guavaOptional      = "com.google.common.base.Optional"
+)
+
+// isOptionalMessage reports whether the property holds an Optional of its message with guava=true,
+// the members of oneofs stay null when unset as the case tells which one is set
+func (g *Generator) isOptionalMessage(f *JavaField) bool {
+	return g.Guava && f.Value.Kind == MessageKind && !f.Repeated && !isRealOneof(f.Proto)
+}
@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"strings"
+	"time"
+)
+
+// Placeholders recognized in custom header templates
+const (
+	headerFilePlaceholder    = "{{file}}"
+	headerPackagePlaceholder = "{{package}}"
+	headerVersionPlaceholder = "{{version}}"
+)
+
+// deprecationComment is the standard comment added to deprecated
+// messages, fields, enums, and enum values.
+var deprecationComment = "// Deprecated: Do not use."
+
+// populatePreamble generates the package declaration and the header comment of a source file,
+// a custom header replaces the built-in comment and is placed above the package declaration.
+func populatePreamble(g *Generator, packageDecl string, files ...*FileDescriptor) {
+	if g.HeaderTemplate != "" {
+		populateCustomHeader(g, files...)
+		g.P(packageDecl)
+		g.P()
+		return
+	}
+	g.P(packageDecl)
+	populateHeaderComment(g, files...)
+}
+
+// populateCustomHeader generates the header template with its placeholders expanded
+func populateCustomHeader(g *Generator, files ...*FileDescriptor) {
+	names := make([]string, 0, len(files))
+	packages := make([]string, 0, len(files))
+	seen := make(map[string]bool)
+	for _, f := range files {
+		names = append(names, f.GetName())
+		if !seen[f.GetPackage()] {
+			seen[f.GetPackage()] = true
+			packages = append(packages, f.GetPackage())
+		}
+	}
+
+	r := strings.NewReplacer(
+		headerFilePlaceholder, strings.Join(names, ", "),
+		headerPackagePlaceholder, strings.Join(packages, ", "),
+		headerVersionPlaceholder, g.Version,
+	)
+	header := strings.TrimRight(strings.ReplaceAll(g.HeaderTemplate, "\r\n", "\n"), "\n")
+	for _, line := range strings.Split(r.Replace(header), "\n") {
+		g.P(line)
+	}
+	g.P()
+}
+
+// populateHeaderComment generates the built-in header comment
+func populateHeaderComment(g *Generator, files ...*FileDescriptor) {
+	g.P()
+	g.P("// Code generated by ", GeneratorName, ". DO NOT EDIT.")
+	if g.Timestamp {
+		g.P("// ", time.Now().Format("2006-01-02 Mon 15:04:05 UTC-0700"))
+	}
+	g.P("//")
+	deprecated := false
+	for _, f := range files {
+		g.P("//     ", f.GetName())
+		deprecated = deprecated || f.GetOptions().GetDeprecated()
+	}
+	g.P("//")
+	if deprecated {
+		g.P(deprecationComment)
+	}
+	g.P()
+	g.P()
+}
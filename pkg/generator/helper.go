@@ -2,9 +2,9 @@ package generator
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
-	"unicode"
 
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 )
@@ -81,6 +81,12 @@ func isRequired(field *descriptor.FieldDescriptorProto) bool {
 	return field.Label != nil && *field.Label == descriptor.FieldDescriptorProto_LABEL_REQUIRED
 }
 
+// isMessage reports whether the field holds a message, including the legacy proto2 groups
+func isMessage(field *descriptor.FieldDescriptorProto) bool {
+	return field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE ||
+		field.GetType() == descriptor.FieldDescriptorProto_TYPE_GROUP
+}
+
 // Is this field repeated?
 func isRepeated(field *descriptor.FieldDescriptorProto) bool {
 	return field.Label != nil && *field.Label == descriptor.FieldDescriptorProto_LABEL_REPEATED
@@ -324,43 +330,187 @@ func kotlinType(field *descriptor.FieldDescriptorProto) (typeName, defaultValue
 			defaultValue = "\"\""
 		}
 	case descriptor.FieldDescriptorProto_TYPE_BYTES:
-		typeName = "ByteArray"
-		defaultValue = "byteArrayOf()"
+		if repeat {
+			typeName = "List<ByteArray>"
+			defaultValue = "listOf()"
+		} else {
+			typeName = "ByteArray"
+			defaultValue = "byteArrayOf()"
+		}
 	}
 
 	return
 }
 
+// javaBoxedType returns the boxed java type of a single value of the field, used as generic type arguments
+func javaBoxedType(field *descriptor.FieldDescriptorProto) string {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_DOUBLE:
+		return "Double"
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		return "Float"
+	case descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_UINT64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64,
+		descriptor.FieldDescriptorProto_TYPE_SINT64,
+		descriptor.FieldDescriptorProto_TYPE_FIXED64:
+		return "Long"
+	case descriptor.FieldDescriptorProto_TYPE_INT32,
+		descriptor.FieldDescriptorProto_TYPE_UINT32,
+		descriptor.FieldDescriptorProto_TYPE_FIXED32,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32,
+		descriptor.FieldDescriptorProto_TYPE_SINT32:
+		return "Integer"
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return "Boolean"
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return "String"
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return "byte[]"
+	default:
+		return ""
+	}
+}
+
 func javaFieldName(field *descriptor.FieldDescriptorProto) string {
 	return CamelCase(field.GetName())
 }
 
-// javaConverterName return java protobuf converter class name
+// defaultJSONName returns the json name protoc derives from the field name, e.g. user_name -> userName
+func defaultJSONName(name string) string {
+	var b strings.Builder
+	upper := false
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_':
+			upper = true
+		case upper && isASCIILower(c):
+			b.WriteByte(c - 'a' + 'A')
+			upper = false
+		default:
+			b.WriteByte(c)
+			upper = false
+		}
+	}
+	return b.String()
+}
+
+// explicitJSONName returns the json_name declared by the field, or an empty string
+// if the field relies on the name protoc fills in.
+func explicitJSONName(field *descriptor.FieldDescriptorProto) string {
+	if name := field.GetJsonName(); name != "" && name != defaultJSONName(field.GetName()) {
+		return name
+	}
+	return ""
+}
+
+// literalEscapes are the escape sequences java and kotlin string literals share
+var literalEscapes = map[rune]string{
+	'\\': `\\`, '"': `\"`, '\n': `\n`, '\r': `\r`, '\t': `\t`, '\b': `\b`,
+}
+
+// javaStringEscape escapes the text for a java string literal. Other control characters are escaped in octal,
+// javac translates unicode escapes before parsing, a \u000a would end the literal.
+func javaStringEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if e, ok := literalEscapes[r]; ok {
+			b.WriteString(e)
+		} else if r < ' ' || r == 0x7f {
+			_, _ = fmt.Fprintf(&b, `\%03o`, r)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// kotlinStringEscape escapes the text for a kotlin string literal, including string templates.
+// Other control characters are escaped as unicode, kotlin has no octal escapes.
+func kotlinStringEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if e, ok := literalEscapes[r]; ok {
+			b.WriteString(e)
+		} else if r == '$' {
+			b.WriteString(`\$`)
+		} else if r < ' ' || r == 0x7f {
+			_, _ = fmt.Fprintf(&b, `\u%04x`, r)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// hasScalarDefault reports whether the bean property of the field starts with the proto2 default of the field,
+// only string and bytes defaults are carried over
+func hasScalarDefault(field *descriptor.FieldDescriptorProto) bool {
+	if field.DefaultValue == nil || isRepeated(field) || field.OneofIndex != nil {
+		return false
+	}
+	t := field.GetType()
+	return t == descriptor.FieldDescriptorProto_TYPE_STRING || t == descriptor.FieldDescriptorProto_TYPE_BYTES
+}
+
+// byteValues returns the comma-separated signed values of the C-escaped bytes default, the way array literals hold them
+func byteValues(defaultValue string) string {
+	data := unescape(defaultValue)
+	values := make([]string, len(data))
+	for i := 0; i < len(data); i++ {
+		values[i] = strconv.Itoa(int(int8(data[i])))
+	}
+	return strings.Join(values, ", ")
+}
+
+// javaGetterName returns the name of the bean getter of the property, e.g. userName -> getUserName
+func javaGetterName(name string) string {
+	return "get" + strings.ToUpper(name[:1]) + name[1:]
+}
+
+// javaSetterName returns the name of the bean setter of the property, e.g. userName -> setUserName
+func javaSetterName(name string) string {
+	return "set" + strings.ToUpper(name[:1]) + name[1:]
+}
+
+// javaConverterName return java protobuf converter class name, after the java_outer_classname of the file, or its
+// proto package and base name, e.g. ShopOrderPb2JavaBean for shop/order.proto of package shop.order, so that
+// the files of a package or of packages ending alike get converters of their own whatever the files generated along
 func javaConverterName(file *FileDescriptor) string {
 	javaClsName := ""
 	if file.GetOptions() != nil && file.GetOptions().GetJavaOuterClassname() != "" {
 		javaClsName = file.GetOptions().GetJavaOuterClassname()
+	} else if file.GetPackage() != "" {
+		javaClsName = javaCamelCase(file.GetPackage(), true)
+		if base := trimPbPrefix(javaCamelCase(baseName(file.GetName()), true)); !strings.HasSuffix(javaClsName, base) {
+			javaClsName += base
+		}
 	} else {
-		javaClsName = file.GetPackage()
-		parts := strings.Split(javaClsName, ".")
-		javaClsName = parts[len(parts)-1]
+		// no package to be named after
+		javaClsName = javaCamelCase(baseName(file.GetName()), true)
 	}
 
-	if strings.HasPrefix(strings.ToLower(javaClsName), "pb") {
-		javaClsName = javaClsName[2:]
-	}
-	javaClsName = strings.Title(javaClsName)
+	javaClsName = strings.Title(trimPbPrefix(javaClsName))
 	return fmt.Sprintf("%sPb2JavaBean", javaClsName)
 }
 
-// badToUnderscore is the mapping function used to generate Go names from package names,
-// which can be dotted in the input .proto file.  It replaces non-identifier characters such as
-// dot or dash with underscore.
-func badToUnderscore(r rune) rune {
-	if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
-		return r
+// trimPbPrefix removes the pb prefix of the name, e.g. PbProfile -> Profile
+func trimPbPrefix(name string) string {
+	if strings.HasPrefix(strings.ToLower(name), "pb") {
+		return name[2:]
 	}
-	return '_'
+	return name
+}
+
+// bundleName returns the name of the Kotlin source file bundling the beans of the proto file, e.g. acme/user_info.proto -> UserInfoBeans
+func bundleName(file *FileDescriptor) string {
+	return strings.Title(CamelCase(baseName(file.GetName()))) + "Beans"
+}
+
+// aliasesName returns the name of the Kotlin source file holding the type aliases of the public imports, e.g. acme/user.proto -> UserAliases
+func aliasesName(file *FileDescriptor) string {
+	return strings.Title(CamelCase(baseName(file.GetName()))) + "Aliases"
 }
 
 // baseName returns the last path element of the name, with the last dotted suffix removed.
@@ -376,19 +526,6 @@ func baseName(name string) string {
 	return name
 }
 
-func cleanPackageName(name string) JavaPackageName {
-	// name = strings.Map(badToUnderscore, name)
-	// // Identifier must not be keyword or predeclared identifier: insert _.
-	// if isGoKeyword[name] {
-	// 	name = "_" + name
-	// }
-	// // Identifier must not begin with digit: insert _.
-	// if r, _ := utf8.DecodeRuneInString(name); unicode.IsDigit(r) {
-	// 	name = "_" + name
-	// }
-	return JavaPackageName(name)
-}
-
 var escapeChars = [256]byte{
 	'a': '\a', 'b': '\b', 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t', 'v': '\v', '\\': '\\', '"': '"', '\'': '\'', '?': '?',
 }
@@ -449,3 +586,13 @@ func unescape(s string) string {
 
 	return string(out)
 }
+
+// sortedKeys returns the keys of the set in increasing order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
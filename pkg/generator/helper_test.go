@@ -0,0 +1,143 @@
+package generator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/master-g/protoc-gen-bean/pkg/generator"
+)
+
+// TestScalarDefaults checks that the proto2 defaults of string and bytes fields are escaped into valid literals
+func TestScalarDefaults(t *testing.T) {
+	tests := []struct {
+		parameter string
+		want      map[string][]string
+	}{
+		{"", map[string][]string{"Stock.kt": {
+			`var note: String = "\n\"x\"\\ \$y\u0001"`,
+			`var tag: ByteArray = byteArrayOf(-128, 97, 98, 0)`,
+		}}},
+		{"lang=java", map[string][]string{"Stock.java": {
+			`private String note = "\n\"x\"\\ $y\001";`,
+			`private byte[] tag = new byte[]{-128, 97, 98, 0};`,
+		}}},
+	}
+	for _, tt := range tests {
+		req := fixturesRequest(t, tt.parameter)
+		for _, f := range req.ProtoFile {
+			if f.GetName() != "shop/legacy.proto" {
+				continue
+			}
+			f.MessageType[0].Field = append(f.MessageType[0].Field,
+				&descriptor.FieldDescriptorProto{
+					Name:         proto.String("note"),
+					Number:       proto.Int32(5),
+					Label:        descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:         descriptor.FieldDescriptorProto_TYPE_STRING.Enum(),
+					DefaultValue: proto.String("\n\"x\"\\ $y\x01"),
+				},
+				&descriptor.FieldDescriptorProto{
+					Name:         proto.String("tag"),
+					Number:       proto.Int32(6),
+					Label:        descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:         descriptor.FieldDescriptorProto_TYPE_BYTES.Enum(),
+					DefaultValue: proto.String(`\200ab\000`),
+				})
+		}
+		resp, err := generator.Run(req, generator.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertContains(t, resp, tt.want)
+	}
+}
+
+// emptyPackageRequest returns a request of proto files without a package statement, point.proto declaring
+// Point and shape.proto declaring Polygon referring to it, with java_package unless the protobuf-java classes
+// are left in the unnamed package
+func emptyPackageRequest(parameter string, javaPackage bool) *plugin.CodeGeneratorRequest {
+	options := func(pkg string) *descriptor.FileOptions {
+		if !javaPackage {
+			return nil
+		}
+		return &descriptor.FileOptions{JavaPackage: proto.String(pkg)}
+	}
+	point := &descriptor.FileDescriptorProto{
+		Name:    proto.String("point.proto"),
+		Syntax:  proto.String("proto3"),
+		Options: options("com.example.geo"),
+		MessageType: []*descriptor.DescriptorProto{{
+			Name: proto.String("Point"),
+			Field: []*descriptor.FieldDescriptorProto{{
+				Name:     proto.String("x"),
+				JsonName: proto.String("x"),
+				Number:   proto.Int32(1),
+				Label:    descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptor.FieldDescriptorProto_TYPE_INT32.Enum(),
+			}},
+		}},
+	}
+	shape := &descriptor.FileDescriptorProto{
+		Name:       proto.String("shape.proto"),
+		Syntax:     proto.String("proto3"),
+		Options:    options("com.example.nopkg"),
+		Dependency: []string{"point.proto"},
+		MessageType: []*descriptor.DescriptorProto{{
+			Name: proto.String("Polygon"),
+			Field: []*descriptor.FieldDescriptorProto{{
+				Name:     proto.String("points"),
+				JsonName: proto.String("points"),
+				Number:   proto.Int32(1),
+				Label:    descriptor.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+				Type:     descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+				TypeName: proto.String(".Point"),
+			}},
+		}},
+	}
+	return &plugin.CodeGeneratorRequest{
+		ProtoFile:      []*descriptor.FileDescriptorProto{point, shape},
+		FileToGenerate: []string{"point.proto", "shape.proto"},
+		Parameter:      proto.String(parameter),
+	}
+}
+
+func TestEmptyPackage(t *testing.T) {
+	var log strings.Builder
+	resp, err := generator.Run(emptyPackageRequest("lang=java", true), generator.Options{Log: &log})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, resp, map[string][]string{
+		"com/example/nopkg/vo/Polygon.java": {
+			"package com.example.nopkg.vo;",
+			"private List<Point> points = new ArrayList<>();",
+			"import com.example.geo.vo.Point;",
+		},
+		"com/example/nopkg/vo/converter/ShapePb2JavaBean.java": {
+			"public static Polygon toBean(com.example.nopkg.Shape.Polygon pb) {",
+			"bean.getPoints().add(com.example.geo.vo.converter.PointPb2JavaBean.toBean(v));",
+		},
+		"com/example/geo/vo/converter/PointPb2JavaBean.java": {
+			"public static Point toBean(com.example.geo.PointOuterClass.Point pb) {",
+		},
+		"com/example/geo/vo/converter/TypeRegistry.java": {
+			`case "Polygon":`,
+		},
+	})
+	if strings.Contains(log.String(), "unnamed package") {
+		t.Errorf("warned of the unnamed package with java_package set:\n%s", log.String())
+	}
+
+	for _, parameter := range []string{"lang=java", "lang=kotlin"} {
+		log.Reset()
+		if _, err = generator.Run(emptyPackageRequest(parameter, false), generator.Options{Log: &log}); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(log.String(), "shape.proto: the protobuf-java classes are generated in the unnamed package") {
+			t.Errorf("%s: no warning of the unnamed package:\n%s", parameter, log.String())
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package generator
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// javaLangNames are the classes of java.lang the java emitters refer to by their simple names,
+// importing another class of the same name would shadow them
+var javaLangNames = []string{
+	"Boolean", "Deprecated", "Double", "Float", "IllegalArgumentException", "Integer", "Long",
+	"Object", "Override", "String", "SuppressWarnings", "ThreadLocal",
+}
+
+// kotlinDefaultNames are the classes imported by default in kotlin the kotlin emitters refer to by their simple names,
+// importing another class of the same name would shadow them
+var kotlinDefaultNames = []string{
+	"Any", "Boolean", "BooleanArray", "ByteArray", "Double", "DoubleArray", "Float", "FloatArray",
+	"IllegalArgumentException", "Int", "IntArray", "JvmStatic", "List", "Long", "LongArray", "Map",
+	"String", "Suppress",
+}
+
+// importSet collects the classes referred to by the source file being generated,
+// its import block is printed once the body is, so that it holds exactly the classes the body refers to.
+// Until then the body refers to the classes by placeholders, see AddImport, as a simple name shared by two classes
+// is only known once the whole body is generated.
+type importSet struct {
+	pkg     string            // Package of the file, its classes need no import
+	offset  int               // Position of the import block in the output
+	classes map[string]string // Classes the simple names refer to whatever else the file refers to, empty for names shadowed by nested classes
+	pinned  []string          // Classes imported by plugins, see Plugin.GenerateImports
+	refs    []string          // Fully-qualified classes referred to by placeholders, by placeholder number
+	ids     map[string]int    // Placeholder numbers by fully-qualified class
+}
+
+// beginImports starts collecting the imports of a source file of the package, printed at the current position,
+// classes are the top-level classes declared by the file.
+func (g *Generator) beginImports(pkg string, classes ...string) {
+	s := &importSet{pkg: pkg, offset: g.Len(), classes: make(map[string]string), ids: make(map[string]int)}
+	if g.lang == LangJava {
+		for _, name := range javaLangNames {
+			s.classes[name] = "java.lang." + name
+		}
+	} else {
+		for _, name := range kotlinDefaultNames {
+			s.classes[name] = ""
+		}
+	}
+	for _, name := range classes {
+		s.classes[name] = pkg + "." + name
+	}
+	g.imports = s
+}
+
+// reserveNested keeps the names of the classes nested in the bean from being imported,
+// as they shadow the imported classes of the same names in the body of the bean.
+func (g *Generator) reserveNested(c *JavaClass) {
+	if g.FieldsEnum {
+		g.imports.classes[fieldsEnumName] = ""
+	}
+	for _, o := range c.Oneofs {
+		g.imports.classes[o.CaseName()] = ""
+	}
+	for _, e := range c.Enums {
+		g.imports.classes[e.Name] = ""
+	}
+	for _, nested := range c.Nested {
+		g.imports.classes[nested.Name] = ""
+		g.reserveNested(nested)
+	}
+}
+
+// AddImport imports the top-level class into the source file being generated and returns the name referring to it,
+// its simple name, or its fully-qualified name when the simple name refers to another class in the file.
+// Two classes of the same simple name are both referred to by their fully-qualified names, the name returned
+// is then a placeholder replaced once the body is generated, it must be printed as is.
+func (g *Generator) AddImport(class string) string {
+	s := g.imports
+	i := strings.LastIndexByte(class, '.')
+	if s == nil || i < 0 {
+		return class
+	}
+	if reserved, ok := s.classes[class[i+1:]]; ok {
+		if reserved == class {
+			return class[i+1:]
+		}
+		return class
+	}
+	id, ok := s.ids[class]
+	if !ok {
+		id = len(s.refs)
+		s.ids[class] = id
+		s.refs = append(s.refs, class)
+	}
+	return importPlaceholder(id)
+}
+
+// pinImport imports the class whatever else the file refers to, for code referring to it by its simple name,
+// unless the simple name already refers to another class in the file
+func (g *Generator) pinImport(class string) {
+	s := g.imports
+	i := strings.LastIndexByte(class, '.')
+	if s == nil || i < 0 {
+		return
+	}
+	if _, ok := s.classes[class[i+1:]]; !ok {
+		s.classes[class[i+1:]] = class
+		s.pinned = append(s.pinned, class)
+	}
+}
+
+// importPlaceholder returns the placeholder of the class referred to, see expand
+func importPlaceholder(id int) string {
+	return "\x00" + strconv.Itoa(id) + "\x00"
+}
+
+// annotatedPlaceholder returns the placeholder annotated with the type-use annotation, the name referring to
+// the annotation class, which goes right before the simple name of the class however the placeholder is expanded,
+// e.g. java.time.@Nullable Instant, as annotating the qualifier does not compile
+func annotatedPlaceholder(placeholder, annotation string) string {
+	return strings.TrimSuffix(placeholder, "\x00") + "@" + strings.Trim(annotation, "\x00") + "\x00"
+}
+
+// expandPlaceholder returns the name the placeholder, without its delimiters, expands to
+func expandPlaceholder(placeholder string, names []string) (string, bool) {
+	ref, annotation := placeholder, ""
+	if i := strings.IndexByte(placeholder, '@'); i >= 0 {
+		ref, annotation = placeholder[:i], placeholder[i+1:]
+		if id, err := strconv.Atoi(annotation); err == nil && id < len(names) {
+			annotation = names[id]
+		}
+	}
+	id, err := strconv.Atoi(ref)
+	if err != nil || id >= len(names) {
+		return "", false
+	}
+	if annotation == "" {
+		return names[id], true
+	}
+	i := strings.LastIndexByte(names[id], '.') + 1
+	return names[id][:i] + "@" + annotation + " " + names[id][i:], true
+}
+
+// used returns the placeholder numbers the text refers to, annotations of annotated placeholders included
+func (s *importSet) used(text string) map[int]bool {
+	used := make(map[int]bool)
+	for i, part := range strings.Split(text, "\x00") {
+		if i%2 == 0 {
+			continue
+		}
+		for _, ref := range strings.Split(part, "@") {
+			if id, err := strconv.Atoi(ref); err == nil {
+				used[id] = true
+			}
+		}
+	}
+	return used
+}
+
+// resolve returns the names referring to the classes of the placeholders and the classes to import,
+// the classes the body ends up not referring to are not imported, e.g. those of discarded output
+func (s *importSet) resolve(body string) (names, imports []string) {
+	used := s.used(body)
+	shared := make(map[string]int)
+	for id, class := range s.refs {
+		if used[id] {
+			shared[class[strings.LastIndexByte(class, '.')+1:]]++
+		}
+	}
+	names = make([]string, len(s.refs))
+	imports = append(imports, s.pinned...)
+	for id, class := range s.refs {
+		name := class[strings.LastIndexByte(class, '.')+1:]
+		if !used[id] {
+			names[id] = class
+		} else if reserved, ok := s.classes[name]; ok && reserved != class {
+			// declared, imported by a plugin after the placeholder was returned, or shadowed by a nested class
+			names[id] = class
+		} else if ok || shared[name] == 1 {
+			names[id] = name
+			imports = append(imports, class)
+		} else {
+			names[id] = class
+		}
+	}
+	return names, imports
+}
+
+// expand replaces the placeholders of the text by the names referring to their classes
+func (s *importSet) expand(text string, names []string) string {
+	if !strings.Contains(text, "\x00") {
+		return text
+	}
+	parts := strings.Split(text, "\x00")
+	var b strings.Builder
+	for i, part := range parts {
+		if i%2 == 1 {
+			if name, ok := expandPlaceholder(part, names); ok {
+				b.WriteString(name)
+				continue
+			}
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// textWidth returns the number of columns of the text once printed, counting placeholders as the simple names
+// of their classes, the narrowest they expand to
+func (g *Generator) textWidth(text string) int {
+	if !strings.Contains(text, "\x00") || g.imports == nil {
+		return utf8.RuneCountInString(text)
+	}
+	names := make([]string, len(g.imports.refs))
+	for id, class := range g.imports.refs {
+		names[id] = class[strings.LastIndexByte(class, '.')+1:]
+	}
+	return utf8.RuneCountInString(g.imports.expand(text, names))
+}
+
+// beanRef returns the name referring to the bean of the object in the source file being generated,
+// importing its outermost class, e.g. User.Address
+func (g *Generator) beanRef(obj Object) string {
+	names := append([]string{g.AddImport(g.beanRootImport(obj))}, g.beanTypeName(obj)[1:]...)
+	return dottedSlice(names)
+}
+
+// printImports ends the collection started by beginImports and inserts the import block before the body.
+// The imports are grouped as IDEs order them, the beans and converters of the request, the other libraries,
+// then the standard libraries, each group sorted and followed by an empty line.
+func (g *Generator) printImports() {
+	s := g.imports
+	g.imports = nil
+	body := string(g.Bytes()[s.offset:])
+	names, imported := s.resolve(body)
+	body = s.expand(body, names)
+	g.Truncate(s.offset)
+
+	var project, thirdParty, std []string
+	for _, class := range imported {
+		pkg := class[:strings.LastIndexByte(class, '.')]
+		switch {
+		case pkg == s.pkg || pkg == "java.lang":
+			continue
+		case strings.HasPrefix(class, "java.") || strings.HasPrefix(class, "javax.") || strings.HasPrefix(class, "kotlin."):
+			std = append(std, class)
+		case g.isProjectPackage(pkg):
+			project = append(project, class)
+		default:
+			thirdParty = append(thirdParty, class)
+		}
+	}
+	terminator := ""
+	if g.lang == LangJava {
+		terminator = ";"
+	}
+	for _, group := range [][]string{project, thirdParty, std} {
+		if len(group) == 0 {
+			continue
+		}
+		if g.WildcardImports > 0 {
+			group = wildcardImports(group, g.WildcardImports)
+		}
+		sort.Strings(group)
+		for _, class := range group {
+			g.P("import ", class, terminator)
+		}
+		g.Newline()
+	}
+	_, _ = g.WriteString(body)
+}
+
+// wildcardImports replaces the classes of the packages imported at least n times by a wildcard import of the package
+func wildcardImports(classes []string, n int) []string {
+	counts := make(map[string]int)
+	for _, class := range classes {
+		counts[class[:strings.LastIndexByte(class, '.')]]++
+	}
+	sl := make([]string, 0, len(classes))
+	wildcard := make(map[string]bool)
+	for _, class := range classes {
+		pkg := class[:strings.LastIndexByte(class, '.')]
+		if counts[pkg] < n {
+			sl = append(sl, class)
+		} else if !wildcard[pkg] {
+			wildcard[pkg] = true
+			sl = append(sl, pkg+".*")
+		}
+	}
+	return sl
+}
+
+// parseWildcardImports validates the wildcard_imports parameter
+func (g *Generator) parseWildcardImports(v string) int {
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		g.Fail("invalid wildcard_imports", v+", use the number of classes of a package imported with a wildcard, or 0")
+	}
+	return n
+}
+
+// isProjectPackage reports whether the java package holds beans or converters of the files of the request
+func (g *Generator) isProjectPackage(pkg string) bool {
+	if g.projectPackages == nil {
+		g.projectPackages = make(map[string]bool)
+		for _, file := range g.allFiles {
+			g.projectPackages[file.importPath.String()] = true
+			g.projectPackages[g.converterPackage(file)] = true
+		}
+	}
+	return g.projectPackages[pkg]
+}
@@ -0,0 +1,153 @@
+package generator
+
+import "testing"
+
+func TestAddImport(t *testing.T) {
+	g := New()
+	g.lang = LangJava
+	g.writeOutput = true
+	g.allFiles = []*FileDescriptor{{importPath: "com.acme.vo"}, {importPath: "com.acme.other.vo"}}
+	g.P("package com.acme.vo;")
+	g.beginImports("com.acme.vo", "User")
+
+	tests := []struct {
+		class string
+		want  string
+	}{
+		{"com.acme.vo.User", "User"},
+		{"com.acme.vo.Order", "com.acme.vo.Order"},
+		{"java.util.List", "java.util.List"},
+		{"com.acme.other.vo.List", "com.acme.other.vo.List"},
+		{"java.util.List", "java.util.List"},
+		{"com.acme.other.vo.User", "com.acme.other.vo.User"},
+		{"com.acme.other.vo.Order", "com.acme.other.vo.Order"},
+		{"com.acme.other.vo.String", "com.acme.other.vo.String"},
+		{"java.lang.String", "String"},
+		{"com.acme.other.vo.Money", "Money"},
+		{"com.google.protobuf.ByteString", "ByteString"},
+		{"com.acme.vo.converter.CommonPb2JavaBean", "CommonPb2JavaBean"},
+		{"com.google.protobuf.ByteString", "ByteString"},
+	}
+	g.P("public class User {")
+	for _, tt := range tests {
+		g.P(g.AddImport(tt.class))
+	}
+	g.P("}")
+	g.printImports()
+
+	want := "package com.acme.vo;\n" +
+		"import com.acme.other.vo.Money;\n" +
+		"import com.acme.vo.converter.CommonPb2JavaBean;\n" +
+		"\n" +
+		"import com.google.protobuf.ByteString;\n" +
+		"\n" +
+		"public class User {\n"
+	for _, tt := range tests {
+		want += tt.want + "\n"
+	}
+	want += "}\n"
+	if got := g.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPinImport(t *testing.T) {
+	g := New()
+	g.writeOutput = true
+	g.beginImports("com.acme.vo", "User")
+	early := g.AddImport("com.acme.other.vo.Status")
+	// imported by a plugin referring to it as Status
+	g.pinImport("com.acme.plugin.Status")
+	g.P(early, " ", g.AddImport("com.acme.plugin.Status"))
+	g.printImports()
+	want := "import com.acme.plugin.Status\n\ncom.acme.other.vo.Status Status\n"
+	if got := g.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUnusedImport(t *testing.T) {
+	g := New()
+	g.lang = LangJava
+	g.writeOutput = true
+	g.beginImports("com.acme.vo", "User")
+	// discarded, e.g. the initial value of a property printed without it
+	g.AddImport("java.util.ArrayList")
+	g.AddImport("com.acme.other.vo.List")
+	g.P(g.AddImport("java.util.List"))
+	g.printImports()
+	want := "import java.util.List;\n\nList\n"
+	if got := g.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestAddImportKotlin(t *testing.T) {
+	g := New()
+	g.beginImports("com.acme.vo", "User")
+
+	// kotlin.collections.List is used without an import
+	if got := g.AddImport("com.acme.other.vo.List"); got != "com.acme.other.vo.List" {
+		t.Errorf("got %s, want the fully-qualified name", got)
+	}
+	if got := g.AddImport("com.acme.vo.List"); got != "com.acme.vo.List" {
+		t.Errorf("got %s, want the fully-qualified name", got)
+	}
+}
+
+func TestWildcardImports(t *testing.T) {
+	g := New()
+	g.WildcardImports = 2
+	g.writeOutput = true
+	g.P("package com.acme.vo")
+	g.beginImports("com.acme.vo", "User")
+	for _, class := range []string{"java.util.UUID", "com.acme.other.vo.Money", "java.time.Instant", "com.acme.other.vo.Order", "java.time.Duration"} {
+		g.P(g.AddImport(class))
+	}
+	g.printImports()
+	want := "package com.acme.vo\n" +
+		"import com.acme.other.vo.*\n" +
+		"\n" +
+		"import java.time.*\n" +
+		"import java.util.UUID\n" +
+		"\n" +
+		"UUID\nMoney\nInstant\nOrder\nDuration\n"
+	if got := g.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestNullableType(t *testing.T) {
+	g := New()
+	g.lang = LangJava
+	g.writeOutput = true
+	g.P("package com.acme.vo;")
+	g.beginImports("com.acme.vo", "User")
+
+	tests := []struct {
+		typeName string
+		want     string
+	}{
+		{g.AddImport("java.time.Instant"), "@Nullable Instant"},
+		{g.AddImport("com.acme.other.vo.User"), "com.acme.other.vo.@Nullable User"},
+		{"User.Address", "User.@Nullable Address"},
+		{"byte[]", "byte @Nullable []"},
+		{"Integer", "@Nullable Integer"},
+	}
+	for _, tt := range tests {
+		g.P(g.nullableType(tt.typeName))
+	}
+	g.printImports()
+
+	want := "package com.acme.vo;\n" +
+		"import org.jspecify.annotations.Nullable;\n" +
+		"\n" +
+		"import java.time.Instant;\n" +
+		"\n"
+	for _, tt := range tests {
+		want += tt.want + "\n"
+	}
+	if got := g.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
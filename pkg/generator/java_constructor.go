@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+)
+
+// The constructors generated besides the no-arg one, chosen by the constructors parameter
+const (
+	constructorAll      = "all"      // Setting every property
+	constructorRequired = "required" // Setting the proto2 required fields and the messages required by protoc-gen-validate
+)
+
+// maxConstructorSlots is the number of parameter slots of a java method, this included, long and double taking two
+const maxConstructorSlots = 255
+
+// javaConstructor is a constructor of a bean rendered by the constructor template
+type javaConstructor struct {
+	Class  string
+	Params []javaProperty
+	Wrap   bool // Whether the parameters are wrapped one per line, the signature being wider than line_width
+}
+
+// parseConstructors validates the constructors parameter
+func (g *Generator) parseConstructors(v string) []string {
+	var kinds []string
+	for _, kind := range strings.Split(v, ";") {
+		switch kind {
+		case "":
+		case constructorAll, constructorRequired:
+			kinds = append(kinds, kind)
+		default:
+			g.Fail("invalid constructors", kind+", use all, required or both separated by ;")
+		}
+	}
+	return kinds
+}
+
+// javaConstructors returns the constructors of the bean, none unless set by the constructors parameter.
+// A constructor exceeding the parameter slots of the jvm is left out with a warning.
+func javaConstructors(g *Generator, c *JavaClass) []javaConstructor {
+	if len(g.Constructors) == 0 {
+		return nil
+	}
+	constructors := []javaConstructor{{Class: c.Name}}
+	all := javaProperties(g, c)
+	var required []javaProperty
+	for i, f := range c.Fields {
+		if f.Oneof == nil && isFieldRequired(f.Proto) {
+			required = append(required, all[i])
+		}
+	}
+	for _, kind := range g.Constructors {
+		params := all
+		if kind == constructorRequired {
+			if len(required) == 0 || len(required) == len(all) {
+				// the constructor would clash with the no-arg or the all-args one
+				continue
+			}
+			params = required
+		} else if len(all) == 0 {
+			continue
+		}
+		slots := 1 + parameterSlots(params, "long", "double")
+		if slots > maxConstructorSlots {
+			g.Warn(warnConstructor, "the", kind, "constructor of", protoFullName(c.Desc), "is left out, its",
+				strconv.Itoa(slots), "parameter slots exceed the", strconv.Itoa(maxConstructorSlots), "of the jvm")
+			continue
+		}
+		constructors = append(constructors, javaConstructor{Class: c.Name, Params: params})
+	}
+	depth := len(c.Desc.TypeName())
+	for i := range constructors {
+		signature := javaSignature("public "+c.Name, constructors[i].Params, " {")
+		constructors[i].Wrap = depth*len(DefaultIndent)+g.textWidth(signature) > g.LineWidth
+	}
+	return constructors
+}
+
+// parameterSlots returns the parameter slots taken by the parameters, two for those of the wide types
+func parameterSlots(params []javaProperty, wide ...string) int {
+	slots := 0
+	for _, p := range params {
+		slots++
+		for _, w := range wide {
+			if p.Type == w {
+				slots++
+			}
+		}
+	}
+	return slots
+}
+
+// javaProperties returns the properties of the bean as the accessors declare them,
+// the fields followed by the cases of the oneofs and the extension values
+func javaProperties(g *Generator, c *JavaClass) []javaProperty {
+	props := make([]javaProperty, 0, len(c.Fields)+len(c.Oneofs)+1)
+	for _, f := range c.Fields {
+		props = append(props, javaFieldProperty(g, f))
+	}
+	for _, o := range c.Oneofs {
+		props = append(props, javaProperty{Type: o.CaseName(), Name: o.Name + "Case"})
+	}
+	if c.Extendable {
+		props = append(props, javaProperty{Type: g.AddImport("java.util.Map") + "<String, Object>", Name: "extensions"})
+	}
+	return props
+}
+
+// javaSignature returns the signature of the method on a single line, e.g. public Point(int x, int y) {
+func javaSignature(method string, params []javaProperty, end string) string {
+	decls := make([]string, len(params))
+	for i, p := range params {
+		decls[i] = p.Type + " " + p.Name
+	}
+	return method + "(" + strings.Join(decls, ", ") + ")" + end
+}
@@ -0,0 +1,35 @@
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/master-g/protoc-gen-bean/pkg/generator"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// TestConstructorsRequiredRule checks a message field required by a validate rule is a parameter of the
+// required constructor, the golden files cover the fixtures without rules
+func TestConstructorsRequiredRule(t *testing.T) {
+	req := fixturesRequest(t, "lang=java,constructors=required")
+	for _, f := range req.ProtoFile {
+		if f.GetName() != "shop/order.proto" {
+			continue
+		}
+		// (validate.rules).message.required = true on Item.price
+		rules := protowire.AppendTag(nil, 2, protowire.VarintType)
+		rules = protowire.AppendVarint(rules, 1)
+		message := protowire.AppendTag(nil, 17, protowire.BytesType)
+		message = protowire.AppendBytes(message, rules)
+		option := protowire.AppendTag(nil, 1071, protowire.BytesType)
+		option = protowire.AppendBytes(option, message)
+		price := f.MessageType[0].NestedType[0].Field[2]
+		price.Options = &descriptor.FieldOptions{}
+		price.Options.ProtoReflect().SetUnknown(option)
+	}
+	resp, err := generator.Run(req, generator.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, resp, map[string][]string{"Order.java": {"public Item(Money price) {\n            this.price = price;"}})
+}
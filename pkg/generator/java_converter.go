@@ -0,0 +1,432 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// javaProtoValueType returns the protobuf-java type of a single value of the field
+func javaProtoValueType(g *Generator, field *descriptor.FieldDescriptorProto) string {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return "com.google.protobuf.ByteString"
+	case descriptor.FieldDescriptorProto_TYPE_ENUM,
+		descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptor.FieldDescriptorProto_TYPE_GROUP:
+		return g.pbClassName(g.ObjectNamed(field.GetTypeName()))
+	default:
+		return javaBoxedType(field)
+	}
+}
+
+func javaPopulateConverter(g *Generator, c *Converter) {
+	populatePreamble(g, "package "+c.Package+";", c.File)
+	g.beginImports(c.Package, c.Name)
+	g.render("converter", c)
+	g.printImports()
+}
+
+// javaPopulateToBean copies the converted fields and the extensions from the protobuf message into the bean
+func javaPopulateToBean(g *Generator, file *FileDescriptor, c *JavaClass) {
+	oneofDone := make(map[*JavaOneof]bool)
+	for _, f := range c.Fields {
+		if !f.Converted {
+			continue
+		}
+		if isRealOneof(f.Proto) {
+			if !oneofDone[f.Oneof] {
+				oneofDone[f.Oneof] = true
+				javaPopulateOneofToBean(g, file, c, f.Oneof)
+			}
+			continue
+		}
+		javaPopulateFieldToBean(g, file, c, f)
+	}
+	javaPopulateExtensionsToBean(g, file, c.Desc)
+}
+
+// javaPopulateToPb sets the converted fields and the extensions of the bean on the protobuf builder
+func javaPopulateToPb(g *Generator, file *FileDescriptor, c *JavaClass) {
+	oneofDone := make(map[*JavaOneof]bool)
+	for _, f := range c.Fields {
+		if !f.Converted {
+			continue
+		}
+		if isRealOneof(f.Proto) {
+			if !oneofDone[f.Oneof] {
+				oneofDone[f.Oneof] = true
+				javaPopulateOneofToPb(g, file, f.Oneof)
+			}
+			continue
+		}
+		javaPopulateFieldToPb(g, file, f)
+	}
+	javaPopulateExtensionsToPb(g, file, c.Desc)
+}
+
+// javaPopulateOneofToPb sets the member of the oneof the case of the bean points to, as the protobuf message
+// holds one member at a time
+func javaPopulateOneofToPb(g *Generator, file *FileDescriptor, o *JavaOneof) {
+	g.P("switch (bean.", javaGetterName(o.Name+"Case"), "()) {")
+	g.In()
+	for _, f := range o.Fields {
+		if !f.Converted {
+			continue
+		}
+		g.P("case ", f.CaseConstant(), ":")
+		g.In()
+		javaPopulateFieldToPb(g, file, f)
+		g.P("break;")
+		g.Out()
+	}
+	g.P("default:")
+	g.In()
+	g.P("break;")
+	g.Out()
+	g.Out()
+	g.P("}")
+}
+
+func javaPopulateOneofToBean(g *Generator, file *FileDescriptor, c *JavaClass, o *JavaOneof) {
+	caseName := oneofCaseName(c.Desc, o.Fields[0].Proto)
+
+	g.P("switch (pb.get", caseName, "()) {")
+	g.In()
+	for _, f := range o.Fields {
+		if !f.Converted {
+			continue
+		}
+		value := g.converterToBeanValue(file, f.Proto, "pb.get"+javaAccessorName(f.Proto)+"()")
+		g.P("case ", f.CaseConstant(), ":")
+		g.In()
+		g.P("bean.", javaSetterName(f.Name), "(", value, ");")
+		g.P("break;")
+		g.Out()
+	}
+	g.P("default:")
+	g.In()
+	g.P("break;")
+	g.Out()
+	g.Out()
+	g.P("}")
+
+	g.P("bean.", javaSetterName(o.Name+"Case"), "(", g.beanRef(c.Desc), ".", o.CaseName(),
+		".forNumber(pb.get", caseName, "().getNumber()));")
+}
+
+func javaPopulateFieldToBean(g *Generator, file *FileDescriptor, c *JavaClass, f *JavaField) {
+	field := f.Proto
+	name := f.Name
+	accessor := javaAccessorName(field)
+	if g.Guava && isRepeated(field) {
+		javaPopulateImmutableToBean(g, file, f)
+		return
+	}
+	if g.DefensiveCopies && isRepeated(field) {
+		javaPopulateCopiedToBean(g, file, f)
+		return
+	}
+	getter := "bean." + javaGetterName(name) + "()"
+	setAll, setOne := getter+".putAll", getter+".put"
+	if isRepeated(field) && !f.IsMap() {
+		setAll, setOne = getter+".addAll", getter+".add"
+	}
+
+	if f.IsMap() {
+		entry := g.mapEntryOf(field)
+		keyField, valField := entry.Field[0], entry.Field[1]
+		value := g.converterToBeanValue(file, valField, "e.getValue()")
+		if value == "e.getValue()" {
+			g.P(setAll, "(pb.get", accessor, "Map());")
+			return
+		}
+		g.P(fmt.Sprintf("for (java.util.Map.Entry<%s, %s> e : pb.get%sMap().entrySet()) {",
+			javaBoxedType(keyField), javaProtoValueType(g, valField), accessor))
+		g.In()
+		g.P(setOne, "(e.getKey(), ", value, ");")
+		g.Out()
+		g.P("}")
+		return
+	}
+
+	if isRepeated(field) {
+		value := g.converterToBeanValue(file, field, "v")
+		if value == "v" {
+			g.P(setAll, "(pb.get", accessor, "List());")
+			return
+		}
+		g.P("for (", javaProtoValueType(g, field), " v : pb.get", accessor, "List()) {")
+		g.In()
+		g.P(setOne, "(", value, ");")
+		g.Out()
+		g.P("}")
+		return
+	}
+
+	value := g.converterToBeanValue(file, field, "pb.get"+accessor+"()")
+	if g.isOptionalMessage(f) {
+		value = g.AddImport(guavaOptional) + ".of(" + value + ")"
+	}
+	switch {
+	case field.GetProto3Optional():
+		g.P("if (pb.has", accessor, "()) {")
+		g.In()
+		g.P("bean.", javaSetterName(name), "(", value, ");")
+		g.P("bean.", javaSetterName(f.Oneof.Name+"Case"), "(", g.beanRef(c.Desc), ".", f.Oneof.CaseName(), ".",
+			f.CaseConstant(), ");")
+		g.Out()
+		g.P("}")
+	case isMessage(field), g.hasPresence(c, f):
+		g.P("if (pb.has", accessor, "()) {")
+		g.In()
+		g.P("bean.", javaSetterName(name), "(", value, ");")
+		g.Out()
+		g.P("}")
+	default:
+		g.P("bean.", javaSetterName(name), "(", value, ");")
+	}
+}
+
+// javaPopulateImmutableToBean sets the immutable list or map of the bean with guava=true,
+// the converted values are collected by a builder named after the property
+func javaPopulateImmutableToBean(g *Generator, file *FileDescriptor, f *JavaField) {
+	field := f.Proto
+	setter := "bean." + javaSetterName(f.Name)
+	accessor := javaAccessorName(field)
+	builder := f.Name + "Builder"
+
+	if f.IsMap() {
+		immutableMap := g.AddImport(guavaImmutableMap)
+		entry := g.mapEntryOf(field)
+		keyField, valField := entry.Field[0], entry.Field[1]
+		value := g.converterToBeanValue(file, valField, "e.getValue()")
+		if value == "e.getValue()" {
+			g.P(setter, "(", immutableMap, ".copyOf(pb.get", accessor, "Map()));")
+			return
+		}
+		g.P(immutableMap, ".Builder<", javaBoxedType(keyField), ", ", javaValueType(g, f.Value), "> ", builder, " = ",
+			immutableMap, ".builder();")
+		g.P(fmt.Sprintf("for (java.util.Map.Entry<%s, %s> e : pb.get%sMap().entrySet()) {",
+			javaBoxedType(keyField), javaProtoValueType(g, valField), accessor))
+		g.In()
+		g.P(builder, ".put(e.getKey(), ", value, ");")
+		g.Out()
+		g.P("}")
+		g.P(setter, "(", builder, ".build());")
+		return
+	}
+
+	immutableList := g.AddImport(guavaImmutableList)
+	value := g.converterToBeanValue(file, field, "v")
+	if value == "v" {
+		g.P(setter, "(", immutableList, ".copyOf(pb.get", accessor, "List()));")
+		return
+	}
+	g.P(immutableList, ".Builder<", javaValueType(g, f.Value), "> ", builder, " = ", immutableList, ".builder();")
+	g.P("for (", javaProtoValueType(g, field), " v : pb.get", accessor, "List()) {")
+	g.In()
+	g.P(builder, ".add(", value, ");")
+	g.Out()
+	g.P("}")
+	g.P(setter, "(", builder, ".build());")
+}
+
+// javaPopulateCopiedToBean sets the list or map property with defensive_copies=true, the getters returning
+// unmodifiable views, the converted values are collected first and copied by the setter
+func javaPopulateCopiedToBean(g *Generator, file *FileDescriptor, f *JavaField) {
+	field := f.Proto
+	setter := "bean." + javaSetterName(f.Name)
+	accessor := javaAccessorName(field)
+	values := f.Name + "Values"
+
+	if f.IsMap() {
+		entry := g.mapEntryOf(field)
+		keyField, valField := entry.Field[0], entry.Field[1]
+		value := g.converterToBeanValue(file, valField, "e.getValue()")
+		if value == "e.getValue()" {
+			g.P(setter, "(pb.get", accessor, "Map());")
+			return
+		}
+		g.P("java.util.Map<", javaBoxedType(keyField), ", ", javaValueType(g, f.Value), "> ", values, " = new java.util.HashMap<>();")
+		g.P(fmt.Sprintf("for (java.util.Map.Entry<%s, %s> e : pb.get%sMap().entrySet()) {",
+			javaBoxedType(keyField), javaProtoValueType(g, valField), accessor))
+		g.In()
+		g.P(values, ".put(e.getKey(), ", value, ");")
+		g.Out()
+		g.P("}")
+		g.P(setter, "(", values, ");")
+		return
+	}
+
+	value := g.converterToBeanValue(file, field, "v")
+	if value == "v" {
+		g.P(setter, "(pb.get", accessor, "List());")
+		return
+	}
+	g.P("java.util.List<", javaValueType(g, f.Value), "> ", values, " = new java.util.ArrayList<>();")
+	g.P("for (", javaProtoValueType(g, field), " v : pb.get", accessor, "List()) {")
+	g.In()
+	g.P(values, ".add(", value, ");")
+	g.Out()
+	g.P("}")
+	g.P(setter, "(", values, ");")
+}
+
+// javaPopulateExtensionsToBean copies the extensions known in this run from the protobuf message into the bean
+func javaPopulateExtensionsToBean(g *Generator, file *FileDescriptor, msg *Descriptor) {
+	extensions := "bean." + javaGetterName(extensionsFieldName) + "()"
+	for _, ext := range g.extensionsOf(msg) {
+		field := ext.FieldDescriptorProto
+		id := protoJavaExtensionName(ext)
+		key := protoFullName(ext)
+		if !isRepeated(field) {
+			g.P("if (pb.hasExtension(", id, ")) {")
+			g.In()
+			g.P(extensions, ".put(\"", key, "\", ", g.converterToBeanValue(file, field, "pb.getExtension("+id+")"), ");")
+			g.Out()
+			g.P("}")
+			continue
+		}
+		g.P("if (pb.getExtensionCount(", id, ") > 0) {")
+		g.In()
+		value := g.converterToBeanValue(file, field, "v")
+		if value == "v" {
+			g.P(extensions, ".put(\"", key, "\", pb.getExtension(", id, "));")
+		} else {
+			beanType := javaValueType(g, g.buildType(field))
+			g.P("java.util.List<", beanType, "> values = new java.util.ArrayList<>();")
+			g.P("for (", javaProtoValueType(g, field), " v : pb.getExtension(", id, ")) {")
+			g.In()
+			g.P("values.add(", value, ");")
+			g.Out()
+			g.P("}")
+			g.P(extensions, ".put(\"", key, "\", values);")
+		}
+		g.Out()
+		g.P("}")
+	}
+}
+
+// javaPopulateExtensionsToPb sets the extensions known in this run from the bean on the protobuf builder
+func javaPopulateExtensionsToPb(g *Generator, file *FileDescriptor, msg *Descriptor) {
+	exts := g.extensionsOf(msg)
+	if len(exts) == 0 {
+		return
+	}
+	extensions := "bean." + javaGetterName(extensionsFieldName) + "()"
+	g.P("if (", extensions, " != null) {")
+	g.In()
+	for _, ext := range exts {
+		field := ext.FieldDescriptorProto
+		id := protoJavaExtensionName(ext)
+		key := protoFullName(ext)
+		beanType := javaValueType(g, g.buildType(field))
+		g.P("if (", extensions, ".containsKey(\"", key, "\")) {")
+		g.In()
+		if !isRepeated(field) {
+			value := "(" + beanType + ") " + extensions + ".get(\"" + key + "\")"
+			g.P("builder.setExtension(", id, ", ", g.converterToPbValue(file, field, value), ");")
+		} else {
+			g.P("@SuppressWarnings(\"unchecked\")")
+			g.P("java.util.List<", beanType, "> values = (java.util.List<", beanType, ">) ", extensions, ".get(\"", key, "\");")
+			g.P("for (", beanType, " v : values) {")
+			g.In()
+			g.P("builder.addExtension(", id, ", ", g.converterToPbValue(file, field, "v"), ");")
+			g.Out()
+			g.P("}")
+		}
+		g.Out()
+		g.P("}")
+	}
+	g.Out()
+	g.P("}")
+}
+
+// javaPopulateRequiredChecks rejects beans missing a value of a proto2 required field,
+// instead of leaving the failure to the less descriptive check of the protobuf builder
+func javaPopulateRequiredChecks(g *Generator, c *JavaClass) {
+	for _, f := range c.Fields {
+		if !isRequired(f.Proto) || (isScalar(f.Proto) && !g.isBoxedScalar(f)) {
+			// primitives are never null in the bean
+			continue
+		}
+		if g.isOptionalMessage(f) {
+			g.P("if (!bean.", javaGetterName(f.Name), "().isPresent()) {")
+		} else {
+			g.P("if (bean.", javaGetterName(f.Name), "() == null) {")
+		}
+		g.In()
+		g.P("throw new IllegalArgumentException(\"", requiredFieldMessage(c.Desc, f.Proto), "\");")
+		g.Out()
+		g.P("}")
+	}
+}
+
+func javaPopulateFieldToPb(g *Generator, file *FileDescriptor, f *JavaField) {
+	field := f.Proto
+	getter := "bean." + javaGetterName(f.Name) + "()"
+	accessor := javaAccessorName(field)
+
+	if f.IsMap() {
+		entry := g.mapEntryOf(field)
+		keyField, valField := entry.Field[0], entry.Field[1]
+		g.P("if (", getter, " != null) {")
+		g.In()
+		value := g.converterToPbValue(file, valField, "e.getValue()")
+		if value == "e.getValue()" {
+			g.P("builder.putAll", accessor, "(", getter, ");")
+		} else {
+			g.P(fmt.Sprintf("for (java.util.Map.Entry<%s, %s> e : %s.entrySet()) {",
+				javaBoxedType(keyField), javaValueType(g, f.Value), getter))
+			g.In()
+			g.P("builder.put", accessor, "(e.getKey(), ", value, ");")
+			g.Out()
+			g.P("}")
+		}
+		g.Out()
+		g.P("}")
+		return
+	}
+
+	if isRepeated(field) {
+		g.P("if (", getter, " != null) {")
+		g.In()
+		value := g.converterToPbValue(file, field, "v")
+		if value == "v" {
+			g.P("builder.addAll", accessor, "(", getter, ");")
+		} else {
+			g.P("for (", javaValueType(g, g.buildType(field)), " v : ", getter, ") {")
+			g.In()
+			g.P("builder.add", accessor, "(", value, ");")
+			g.Out()
+			g.P("}")
+		}
+		g.Out()
+		g.P("}")
+		return
+	}
+
+	if g.isOptionalMessage(f) {
+		g.P("if (", getter, ".isPresent()) {")
+		g.In()
+		g.P("builder.set", accessor, "(", g.converterToPbValue(file, field, getter+".get()"), ");")
+		g.Out()
+		g.P("}")
+	} else if field.OneofIndex != nil || !isScalar(field) || g.isBoxedScalar(f) {
+		g.P("if (", getter, " != null) {")
+		g.In()
+		g.P("builder.set", accessor, "(", g.converterToPbValue(file, field, getter), ");")
+		g.Out()
+		g.P("}")
+	} else {
+		g.P("builder.set", accessor, "(", getter, ");")
+	}
+}
+
+func javaPopulateTypeRegistry(g *Generator) {
+	populatePreamble(g, "package "+g.registryPackage()+";", g.genFiles...)
+	g.beginImports(g.registryPackage(), typeRegistryName)
+	g.render("registry", g.registryDescriptors())
+	g.printImports()
+}
@@ -2,417 +2,390 @@ package generator
 
 import (
 	"fmt"
-	"sort"
 	"strings"
-	"time"
+	"text/template"
 
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 )
 
 // TODO: add keyword conversion
 
-func javaPopulateHeaderComment(g *Generator, f *FileDescriptor) {
-	g.P()
-	g.P("// Code generated by ", GeneratorName, ". DO NOT EDIT.")
-	if g.NoTime {
-		g.P("// Timestamp generation disabled.")
-	} else {
-		g.P("// ", time.Now().Format("2006-01-02 Mon 15:04:05 UTC-0700"))
-	}
-	g.P("//")
-	g.P("//     ", f.GetName())
-	g.P("//")
-	if f.GetOptions().GetDeprecated() {
-		g.P(deprecationComment)
-	}
-	g.P()
-	g.P()
+// javaProperty is a bean property rendered by the accessor template
+type javaProperty struct {
+	Type  string
+	Name  string
+	Get   string       // Expression returned by the getter, the property itself when empty
+	Set   string       // Expression of the parameter assigned by the setter, the parameter itself when empty
+	Oneof *oneofMember // Case updated by the setter of a member of a oneof
 }
 
-func javaPopulateEnum(g *Generator, enum *EnumDescriptor) {
-	if enum.parent == nil {
-		g.P("package ", enumPackagePath(g, enum), ";")
-		javaPopulateHeaderComment(g, enum.File())
-	}
-
-	if enum.GetOptions().GetDeprecated() {
-		g.P(deprecationComment)
-	}
-
-	g.PrintComments(enum.path)
-	g.P("public enum ", enum.GetName(), " {")
-
-	g.In()
-
-	for i, e := range enum.Value {
-		etorPath := fmt.Sprintf("%s,%d,%d", enum.path, enumValuePath, i)
-		g.PrintComments(etorPath)
-
-		tails, ok := g.tailingComments(etorPath)
-		if !ok {
-			tails = ""
-		} else {
-			tails = fmt.Sprintf(" %s", tails)
-		}
-
-		if i == len(enum.Value)-1 {
-			g.P(e.GetName(), "(", e.Number, ");", tails)
-		} else {
-			g.P(e.GetName(), "(", e.Number, "),", tails)
-		}
-	}
-	g.Newline()
-	g.P("public int code;")
-	g.Newline()
-	g.P(enum.GetName(), "(int code) { ")
-	g.In()
-	g.P("this.code = code;")
-	g.Out()
-	g.P("}")
-	g.Newline()
-	g.P("/**")
-	g.P(" * @deprecated Use {@link #forNumber(int)} instead.")
-	g.P(" */")
-	g.P("@java.lang.Deprecated")
-	g.P("public static ", enum.GetName(), " valueOf(int value) {")
-	g.In()
-	g.P("return forNumber(value);")
-	g.Out()
-	g.P("}")
-	g.Newline()
-	g.P("public static ", enum.GetName(), " forNumber(int value) {")
-	g.In()
-	g.P("switch (value) {")
-	g.In()
-	for i := 0; i < len(enum.Value); i++ {
-		e := enum.Value[(i+1)%len(enum.Value)]
-		if i != len(enum.Value)-1 {
-			g.P("case ", e.Number, ":")
-			g.In()
-			g.P("return ", e.GetName(), ";")
-			g.Out()
-		} else {
-			g.P("default:")
-			g.In()
-			g.P("return ", e.GetName(), ";")
-			g.Out()
-		}
+// javaTemplateFuncs returns the functions spelling the model in java for the templates
+func javaTemplateFuncs(g *Generator) template.FuncMap {
+	return template.FuncMap{
+		"fieldType": func(f *JavaField) string {
+			typeName, _ := javaFieldType(g, f)
+			if g.isNullable(f) {
+				return g.nullableType(typeName)
+			}
+			return typeName
+		},
+		"initialValue": func(f *JavaField) string {
+			_, value := javaFieldType(g, f)
+			return value
+		},
+		"valueType": func(t JavaType) string {
+			return javaValueType(g, t)
+		},
+		"property": func(typeName, name string) javaProperty {
+			return javaProperty{Type: typeName, Name: name}
+		},
+		"fieldProperty": func(f *JavaField) javaProperty {
+			return javaFieldProperty(g, f)
+		},
+		"fieldsEnum": func(c *JavaClass) []fieldMetadata {
+			return g.fieldsEnum(c, javaPropertyType(g), javaClassLiteral, javaStringEscape)
+		},
+		"visit": func(c *JavaClass) *beanVisit {
+			return g.beanVisit(c, g.fieldsMetadata(c, javaPropertyType(g), javaClassLiteral, javaStringEscape))
+		},
+		"equals": func(c *JavaClass) *beanEquals {
+			return g.beanEquals(c, javaEqualsSyntax(g))
+		},
+		"diff": func(c *JavaClass) *beanDiff {
+			nullable := c.Name
+			if g.Nullability == nullabilityJSpecify {
+				nullable = g.nullableType(c.Name)
+			}
+			return g.beanDiff(c, nullable, javaDiffSyntax(g))
+		},
+		"viewProperties": func(c *JavaClass) []javaProperty {
+			return viewProperties(c, javaProperties(g, c))
+		},
+		"getter": javaGetterName,
+		"setter": javaSetterName,
+		"constructors": func(c *JavaClass) []javaConstructor {
+			return javaConstructors(g, c)
+		},
+		"mutators": func(c *JavaClass) []collectionMutator {
+			if !g.Mutators {
+				return nil
+			}
+			mutators := collectionMutators(c, func(t JavaType) string { return javaValueType(g, t) },
+				func(f *JavaField) (string, string) { return javaFieldType(g, f) })
+			for i := range mutators {
+				mutators[i].Guava = g.Guava
+			}
+			return mutators
+		},
+		"factories": func(c *JavaClass) *beanFactories {
+			signature := func(params []javaProperty) string {
+				return javaSignature("public static "+c.Name+" of", params, " {")
+			}
+			params := javaProperties(g, c)
+			// static, without this
+			return g.beanFactories(c, params, parameterSlots(params, "long", "double"), signature, len(c.Desc.TypeName())*len(DefaultIndent))
+		},
+		"javaVersion": func() int {
+			return g.JavaVersion
+		},
+		"switchExpressions": g.switchExpressions,
+		"defaultValue":      javaEnumDefault,
+		"switchCases":       javaEnumSwitchCases,
+		"toStringLines": func(c *JavaClass) []string {
+			return g.toStringLines(c, func(f *JavaField) toStringValue { return javaToStringValue(g, f) })
+		},
+		"toBean": func(file *FileDescriptor, c *JavaClass) string {
+			return g.capture(func() { javaPopulateToBean(g, file, c) })
+		},
+		"toPb": func(file *FileDescriptor, c *JavaClass) string {
+			return g.capture(func() { javaPopulateToPb(g, file, c) })
+		},
+		"requiredChecks": func(c *JavaClass) string {
+			return g.capture(func() { javaPopulateRequiredChecks(g, c) })
+		},
+		"toMapBody": func(c *JavaClass) string {
+			return g.capture(func() { javaPopulateToMap(g, c) })
+		},
+		"fromMapBody": func(c *JavaClass) string {
+			return g.capture(func() { javaPopulateFromMap(g, c) })
+		},
+		"uncheckedFromMap": hasMessageValues,
+		"mapValueType": func() string {
+			return javaMapValueType(g)
+		},
 	}
-	g.Out()
-	g.P("}")
-	g.Out()
-	g.P("}")
-
-	g.Out()
-	g.P("}")
 }
 
-func javaExtractImports(g *Generator, msg *Descriptor, sysImp, usrImp map[string]string) {
-	// nested descriptor's fields
-	for _, nested := range msg.nested {
-		javaExtractImports(g, nested, sysImp, usrImp)
+// javaPropertyType returns the function returning the java type of the property of a field
+func javaPropertyType(g *Generator) func(f *JavaField) string {
+	return func(f *JavaField) string {
+		typeName, _ := javaFieldType(g, f)
+		return typeName
 	}
+}
 
-	extractUserImport := func(f *descriptor.FieldDescriptorProto) {
-		obj, ok := g.typeNameToObject[f.GetTypeName()]
-		if !ok {
-			g.Fail("unable to find object with type named,", f.GetTypeName())
-		}
-		// .package.name.TypeName -> package.name.TypeName
-		typeName := f.GetTypeName()[1:]
-		// package.name.TypeName -> TypeName
-		typeName = strings.TrimPrefix(typeName, obj.File().GetPackage())[1:]
-
-		// RootMsg.NestMsg -> RootMsg
-		importPkg := typeName
-		if strings.Contains(typeName, ".") {
-			importPkg = strings.Split(typeName, ".")[0]
-		}
+// javaClassLiteral returns the class literal of the type without arguments, e.g. List.class
+func javaClassLiteral(raw string) string {
+	return raw + ".class"
+}
 
-		fullJavaImportPath := fmt.Sprintf("%s.%s", obj.JavaImportPath().String(), importPkg)
-		usrImp[fullJavaImportPath] = typeName
+// javaDiffSyntax spells the statements of the diff methods in java
+func javaDiffSyntax(g *Generator) diffSyntax {
+	return diffSyntax{
+		Terminator: ";",
+		Ref: func(f *JavaField, bean string) string {
+			if g.isOptionalMessage(f) {
+				return bean + "." + f.Name + ".orNull()"
+			}
+			return bean + "." + f.Name
+		},
+		Path: func(name, index string) string {
+			if index == "" {
+				return `prefix + "` + name + `"`
+			}
+			return `prefix + "` + name + `[" + ` + index + ` + "]"`
+		},
+		Size: func(collection string) string {
+			return collection + ".size()"
+		},
+		Get: func(collection, index string) string {
+			return collection + ".get(" + index + ")"
+		},
+		Indices: func(list string) string {
+			return "for (int i = 0; i < " + list + ".size(); i++)"
+		},
+		Keys: func(f *JavaField, m string) string {
+			return "for (" + javaValueType(g, *f.Key) + " key : " + m + ".keySet())"
+		},
+		Missing: func(m, key string) string {
+			return "!" + m + ".containsKey(" + key + ")"
+		},
+		Changed: func(f *JavaField, a, b string, element bool) string {
+			switch {
+			case f.Value.Kind == ScalarKind && f.Proto.GetType() == descriptor.FieldDescriptorProto_TYPE_BYTES:
+				return "!" + g.AddImport("java.util.Arrays") + ".equals(" + a + ", " + b + ")"
+			case f.Value.Kind == EnumKind,
+				// primitives, unless boxed
+				!element && f.Value.Kind == ScalarKind && f.Oneof == nil && !g.isBoxedScalar(f) &&
+					f.Proto.GetType() != descriptor.FieldDescriptorProto_TYPE_STRING:
+				return a + " != " + b
+			}
+			return "!" + g.AddImport("java.util.Objects") + ".equals(" + a + ", " + b + ")"
+		},
 	}
+}
 
-	for _, field := range msg.Field {
-		switch field.GetType() {
-		case descriptor.FieldDescriptorProto_TYPE_ENUM:
-			fallthrough
-		case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-			desc := g.ObjectNamed(field.GetTypeName())
-			if d, ok := desc.(*Descriptor); ok && d.GetOptions().GetMapEntry() {
-				// Figure out the java types and tags for the key and value type
-				valField := d.Field[1]
-				extractUserImport(valField)
-				sysImp["java.util.HashMap"] = field.GetName()
-				sysImp["java.util.Map"] = field.GetName()
-			} else if isRepeated(field) {
-				sysImp["java.util.ArrayList"] = field.GetName()
-				sysImp["java.util.List"] = field.GetName()
+// javaEqualsSyntax spells the statements of the equals and hashCode methods in java
+func javaEqualsSyntax(g *Generator) equalsSyntax {
+	return equalsSyntax{
+		Terminator: ";",
+		Differ: func(f *JavaField, a, b string) string {
+			switch {
+			case f == nil:
+			case isByteArray(f.Value):
+				return "!" + g.AddImport("java.util.Arrays") + ".equals(" + a + ", " + b + ")"
+			case f.Value.Kind == EnumKind,
+				// primitives, unless boxed
+				f.Value.Kind == ScalarKind && f.Oneof == nil && !g.isBoxedScalar(f) &&
+					f.Proto.GetType() != descriptor.FieldDescriptorProto_TYPE_STRING:
+				return a + " != " + b
 			}
-
-			extractUserImport(field)
-		default:
-			if isRepeated(field) {
-				sysImp["java.util.ArrayList"] = field.GetName()
-				sysImp["java.util.List"] = field.GetName()
+			return "!" + g.AddImport("java.util.Objects") + ".equals(" + a + ", " + b + ")"
+		},
+		Hash: func(f *JavaField, value string) string {
+			if f != nil && isByteArray(f.Value) {
+				return g.AddImport("java.util.Arrays") + ".hashCode(" + value + ")"
 			}
-		}
+			return g.AddImport("java.util.Objects") + ".hashCode(" + value + ")"
+		},
+		ListDiffer: func(a, b string) string {
+			return "!" + g.AddImport("java.util.Arrays") + ".deepEquals(" + a + ".toArray(), " + b + ".toArray())"
+		},
+		ListHash: func(list string) string {
+			return g.AddImport("java.util.Arrays") + ".deepHashCode(" + list + ".toArray())"
+		},
+		Keys: func(f *JavaField, m string) string {
+			return "for (" + javaValueType(g, *f.Key) + " key : " + m + ".keySet())"
+		},
+		Get: func(m, key string) string {
+			return m + ".get(" + key + ")"
+		},
+		Size: func(collection string) string {
+			return collection + ".size()"
+		},
+		KeySet: func(m string) string {
+			return m + ".keySet()"
+		},
 	}
 }
 
-func javaPopulateField(g *Generator, msg *Descriptor, field *descriptor.FieldDescriptorProto, index int) {
-	typeName := ""
-	typeDefaultValue := ""
+// javaPopulateView generates the source file of the read-only view of a top-level bean,
+// the views of nested beans are nested in the enclosing beans
+func javaPopulateView(g *Generator, c *JavaClass) {
+	thisPackage := descriptorPackagePath(g, c.Desc)
+	populatePreamble(g, "package "+thisPackage+";", c.Desc.File())
 
-	switch field.GetType() {
-	case descriptor.FieldDescriptorProto_TYPE_ENUM:
-		fallthrough
-	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-		desc := g.ObjectNamed(field.GetTypeName())
-		if d, ok := desc.(*Descriptor); ok && d.GetOptions().GetMapEntry() {
-			// Figure out the java types and tags for the key and value type
-			keyField, valField := d.Field[0], d.Field[1]
-			typeName, typeDefaultValue = javaPopulateMap(g, keyField, valField)
-		} else {
-			typeName = getFieldTypeName(g, field)
-			if isRepeated(field) {
-				typeName = fmt.Sprintf("List<%s>", typeName)
-				typeDefaultValue = "new ArrayList<>()"
-			} else {
-				typeName = fmt.Sprintf("%s", typeName)
-				typeDefaultValue = "null"
-			}
-		}
-	default:
-		typeName, typeDefaultValue = javaType(field)
-		if typeName == "" {
-			g.Fail("unknown type for", field.GetName())
-		}
-	}
+	g.beginImports(thisPackage, c.View, c.Name)
+	g.render("view", c)
+	g.printImports()
+}
 
-	ftorPath := fmt.Sprintf("%s,%d,%d", msg.path, messageFieldPath, index)
-	if c, ok := g.makeComments(ftorPath); ok {
-		g.Newline()
-		g.P(c)
-	}
-	//g.PrintComments(ftorPath)
-	tail, ok := g.tailingComments(ftorPath)
-	if !ok {
-		tail = ""
-	} else {
-		tail = fmt.Sprintf(" %s", tail)
-	}
-	g.P("public ", typeName, " ", javaFieldName(field), " = ", typeDefaultValue, ";", tail)
+// javaPopulateEnum generates the source file of a top-level enum
+func javaPopulateEnum(g *Generator, e *JavaEnum) {
+	populatePreamble(g, "package "+enumPackagePath(g, e.Desc)+";", e.Desc.File())
+	g.render("enum", e)
 }
 
-func javaPopulateMap(g *Generator, keyField, valField *descriptor.FieldDescriptorProto) (typeName, typeDefaultValue string) {
-	var keyTypeName string
-	switch keyField.GetType() {
-	case descriptor.FieldDescriptorProto_TYPE_ENUM:
-		fallthrough
-	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-		keyTypeName = getFieldTypeName(g, keyField)
-	default:
-		keyTypeName, _ = javaType(keyField)
+// javaEnumDefault returns the default constant of the enum, the first value unless set explicitly
+func javaEnumDefault(e *JavaEnum) *JavaEnumValue {
+	if e.Default < 0 {
+		return e.Values[0]
 	}
+	return e.Values[e.Default]
+}
 
-	var valTypeName string
-	switch valField.GetType() {
-	case descriptor.FieldDescriptorProto_TYPE_ENUM:
-		fallthrough
-	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-		valTypeName = getFieldTypeName(g, valField)
-	default:
-		valTypeName, _ = javaType(valField)
+// javaEnumSwitchCases returns the values matched by the switch of forNumber,
+// the default constant is left to the default branch and the values following it come first
+func javaEnumSwitchCases(e *JavaEnum) []*JavaEnumValue {
+	d := e.Default
+	if d < 0 {
+		d = 0
 	}
-
-	typeName = fmt.Sprintf("Map<%s, %s>", keyTypeName, valTypeName)
-	typeDefaultValue = "new HashMap<>()"
-
-	return
+	cases := make([]*JavaEnumValue, 0, len(e.Values)-1)
+	for i := 1; i < len(e.Values); i++ {
+		cases = append(cases, e.Values[(d+i)%len(e.Values)])
+	}
+	return cases
 }
 
-func javaPopulateToString(g *Generator, msg *Descriptor) {
-	g.P("@Override")
-	g.P("public String toString() {")
-	g.In()
-	g.P("return \"", msg.GetName(), "{\" +")
-	g.In()
-	g.In()
-
-	sb := &strings.Builder{}
-
-	for i, field := range msg.Field {
-		name := javaFieldName(field)
-		repeat := isRepeated(field)
-
-		sb.Reset()
-		sb.WriteByte('"')
-		if i != 0 {
-			sb.WriteString(", ")
-		}
-
-		sb.WriteString(name)
-
-		sb.WriteByte('=')
-		if field.GetType() == descriptor.FieldDescriptorProto_TYPE_STRING && !repeat {
-			sb.WriteByte('\'')
-		}
-
-		sb.WriteString("\" + ")
-
-		switch field.GetType() {
-		case descriptor.FieldDescriptorProto_TYPE_BYTES:
-			if repeat {
-				sb.WriteString(name)
-			} else {
-				sb.WriteString(fmt.Sprintf("%s.length + \" bytes\"", name))
-			}
-		case descriptor.FieldDescriptorProto_TYPE_STRING:
-			sb.WriteString(name)
-			if !repeat {
-				sb.WriteString(" + '\\''")
-			}
-		case descriptor.FieldDescriptorProto_TYPE_ENUM:
-			sb.WriteString(name)
-		case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-			sb.WriteString(name)
-		default:
-			sb.WriteString(name)
-		}
-
-		sb.WriteString(" +")
-
-		g.P(sb.String())
+// javaValueType returns the java type of a single value, boxed as it is used as a type argument of lists and maps
+func javaValueType(g *Generator, t JavaType) string {
+	switch t.Kind {
+	case EnumKind, MessageKind:
+		return g.beanRef(t.Object)
+	case AnyKind:
+		return "Object"
+	case CustomKind:
+		return t.Class
 	}
-	g.P("\"}\";")
-	g.Out()
-	g.Out()
-	g.Out()
-	g.P("}")
+	return javaBoxedType(&descriptor.FieldDescriptorProto{Type: t.Proto.Enum()})
 }
 
-func javaPopulateDescriptor(g *Generator, msg *Descriptor) {
-	// only root messages have package announcement, header and imports
-	if msg.parent == nil {
-		// only root messages have these fancy stuff
-		thisPackage := descriptorPackagePath(g, msg)
-		g.P("package ", thisPackage, ";")
-		javaPopulateHeaderComment(g, msg.File())
-
-		// imports
-		sysImp := make(map[string]string)
-		usrImp := make(map[string]string)
-		javaExtractImports(g, msg, sysImp, usrImp)
-
-		if len(usrImp) > 0 {
-			usrImpKeys := make([]string, 0, len(usrImp))
-			for importPath := range usrImp {
-				usrImpKeys = append(usrImpKeys, importPath)
-			}
-			sort.Strings(usrImpKeys)
-			addParagraph := false
-			for _, p := range usrImpKeys {
-				if !underSamePackage(p, thisPackage) {
-					g.P("import ", p, ";")
-					addParagraph = true
-				}
-			}
-			if addParagraph {
-				g.P()
-			}
+// javaFieldType returns the java type and the initial value of the bean property
+func javaFieldType(g *Generator, f *JavaField) (typeName, typeDefaultValue string) {
+	switch {
+	case f.Value.Kind == CustomKind:
+		typeName, typeDefaultValue = f.Value.Class, "null"
+	case f.IsMap() && g.Guava:
+		typeName = fmt.Sprintf("%s<%s, %s>", g.AddImport(guavaImmutableMap), javaValueType(g, *f.Key), javaValueType(g, f.Value))
+		typeDefaultValue = g.AddImport(guavaImmutableMap) + ".of()"
+	case f.IsMap():
+		typeName = fmt.Sprintf("%s<%s, %s>", g.AddImport("java.util.Map"), javaValueType(g, *f.Key), javaValueType(g, f.Value))
+		typeDefaultValue = "new " + g.AddImport("java.util.HashMap") + "<>()"
+	case f.Repeated && g.Guava:
+		typeName = fmt.Sprintf("%s<%s>", g.AddImport(guavaImmutableList), javaValueType(g, f.Value))
+		typeDefaultValue = g.AddImport(guavaImmutableList) + ".of()"
+	case f.Repeated:
+		typeName = fmt.Sprintf("%s<%s>", g.AddImport("java.util.List"), javaValueType(g, f.Value))
+		typeDefaultValue = "new " + g.AddImport("java.util.ArrayList") + "<>()"
+	case f.Value.Kind == ScalarKind:
+		typeName, typeDefaultValue = javaType(f.Proto)
+		if typeName == "" {
+			g.Fail("unsupported type", f.Proto.GetType().String(), "of field", f.Proto.GetName())
 		}
-
-		if len(sysImp) > 0 {
-			sysImpKeys := make([]string, 0, len(sysImp))
-			for importPath := range sysImp {
-				sysImpKeys = append(sysImpKeys, importPath)
-			}
-			sort.Strings(sysImpKeys)
-			for _, p := range sysImpKeys {
-				g.P("import ", p, ";")
-			}
-			g.P()
+		if g.isBoxedScalar(f) {
+			typeName, typeDefaultValue = javaBoxedType(f.Proto), "null"
+		} else if hasScalarDefault(f.Proto) {
+			typeDefaultValue = javaScalarDefault(f.Proto)
 		}
+	case g.isOptionalMessage(f):
+		typeName = fmt.Sprintf("%s<%s>", g.AddImport(guavaOptional), javaValueType(g, f.Value))
+		typeDefaultValue = g.AddImport(guavaOptional) + ".absent()"
+	default:
+		typeName, typeDefaultValue = javaValueType(g, f.Value), "null"
 	}
+	return
+}
 
-	if msg.GetOptions().GetDeprecated() {
-		g.P(deprecationComment)
+// javaFieldProperty returns the property of the field, copying lists, maps and byte arrays with defensive_copies=true
+func javaFieldProperty(g *Generator, f *JavaField) javaProperty {
+	typeName, _ := javaFieldType(g, f)
+	if g.isNullable(f) {
+		typeName = g.nullableType(typeName)
 	}
-
-	g.PrintComments(msg.path)
-	g.P("public class ", msg.GetName(), " {")
-	g.In()
-
-	// fields
-	oFields := make(map[int32]*oneofField)
-
-	for i, field := range msg.Field {
-		oneof := field.OneofIndex != nil
-		if oneof && oFields[*field.OneofIndex] == nil {
-			odp := msg.OneofDecl[int(*field.OneofIndex)]
-
-			of := oneofField{
-				name:      CamelCase(odp.GetName()),
-				field:     field,
-				subFields: nil,
-			}
-
-			oFields[*field.OneofIndex] = &of
-		}
-
-		if oneof {
-			sf := oneofSubField{
-				index: i,
-				field: field,
-			}
-
-			of := oFields[*field.OneofIndex]
-			if of != nil {
-				of.subFields = append(of.subFields, &sf)
-			}
-		}
-
-		javaPopulateField(g, msg, field, i)
+	present := f.Name + " != null"
+	if g.isOptionalMessage(f) {
+		present = f.Name + ".isPresent()"
 	}
-	g.Out()
-
-	// oneof
-	for _, of := range oFields {
-		g.P()
-		g.In()
-
-		of.populate(g, msg)
-
-		g.Out()
+	p := javaProperty{Type: typeName, Name: f.Name, Oneof: oneofMemberOf(f, present)}
+	if !g.DefensiveCopies || f.Value.Kind == CustomKind {
+		return p
 	}
-
-	// nested enums
-	for _, nestEnum := range msg.enums {
-		g.P()
-		g.In()
-		javaPopulateEnum(g, nestEnum)
-		g.Out()
+	switch {
+	case f.Repeated && g.Guava:
+		// immutable already
+	case f.IsMap():
+		p.Get = g.AddImport("java.util.Collections") + ".unmodifiableMap(" + f.Name + ")"
+		p.Set = "new " + g.AddImport("java.util.HashMap") + "<>(" + f.Name + ")"
+	case f.Repeated:
+		p.Get = g.AddImport("java.util.Collections") + ".unmodifiableList(" + f.Name + ")"
+		p.Set = "new " + g.AddImport("java.util.ArrayList") + "<>(" + f.Name + ")"
+	case f.Proto.GetType() == descriptor.FieldDescriptorProto_TYPE_BYTES && g.isNullScalar(f):
+		// unset members of oneofs and required fields are null
+		p.Get = f.Name + " == null ? null : " + f.Name + ".clone()"
+		p.Set = p.Get
+	case f.Proto.GetType() == descriptor.FieldDescriptorProto_TYPE_BYTES:
+		p.Get = f.Name + ".clone()"
+		p.Set = p.Get
 	}
+	return p
+}
 
-	// nested descriptors
-	for _, nestDesc := range msg.nested {
-		if nestDesc.GetOptions().GetMapEntry() {
-			// Don't generate virtual messages for maps.
-			continue
-		}
-		g.P()
-		g.In()
-		javaPopulateDescriptor(g, nestDesc)
-		g.Out()
+// javaScalarDefault returns the literal of the proto2 default of a string or bytes field
+func javaScalarDefault(field *descriptor.FieldDescriptorProto) string {
+	if field.GetType() == descriptor.FieldDescriptorProto_TYPE_BYTES {
+		return "new byte[]{" + byteValues(field.GetDefaultValue()) + "}"
 	}
+	return `"` + javaStringEscape(field.GetDefaultValue()) + `"`
+}
 
-	if len(msg.Field) > 0 {
-		g.P()
-		g.In()
-		javaPopulateToString(g, msg)
+// javaToStringValue returns how toString prints the value of the property, following its label
+func javaToStringValue(g *Generator, f *JavaField) toStringValue {
+	v := toStringValue{Label: javaStringEscape(f.Label)}
+	ref := g.toStringRef(f)
+	switch {
+	case f.Redacted:
+	case f.Value.Kind == CustomKind:
+		// printed by its own toString, whatever the proto type
+		v.Expr = ref
+	case f.Proto.GetType() == descriptor.FieldDescriptorProto_TYPE_STRING && !f.Repeated:
+		v.Expr, v.Quote = ref, true
+	case f.Proto.GetType() == descriptor.FieldDescriptorProto_TYPE_BYTES && g.isNullScalar(f):
+		v.Expr, v.Suffix = "("+ref+" == null ? null : "+ref+".length)", " bytes"
+	case f.Proto.GetType() == descriptor.FieldDescriptorProto_TYPE_BYTES && !f.Repeated:
+		v.Expr, v.Suffix = ref+".length", " bytes"
+	default:
+		v.Expr = ref
 	}
+	return v
+}
+
+// javaPopulatePublicImport generates the class re-exporting a publicly imported bean in the package
+func javaPopulatePublicImport(g *Generator, thisPackage string, file *FileDescriptor, imp *ImportedDescriptor) {
+	populatePreamble(g, "package "+thisPackage+";", file)
 
-	g.Out()
+	g.P("// ", protoFullName(imp.o), " publicly imported from ", imp.o.File().GetName())
+	g.P("public class ", g.beanName(imp.o), " extends ", strings.Join(getFullPathComponents(g, imp.o.File(), g.beanTypeName(imp.o)), "."), " {")
 	g.P("}")
 }
+
+// javaPopulateDescriptor generates the source file of a top-level message, its nested messages and enums included
+func javaPopulateDescriptor(g *Generator, c *JavaClass) {
+	thisPackage := descriptorPackagePath(g, c.Desc)
+	populatePreamble(g, "package "+thisPackage+";", c.Desc.File())
+
+	g.beginImports(thisPackage, c.Name)
+	g.reserveNested(c)
+	g.render("bean", c)
+	g.printImports()
+}
@@ -0,0 +1,26 @@
+package generator
+
+import "strconv"
+
+// The java language levels of the javaver parameter, the generated code compiles at the level and above
+const (
+	javaVersion7       = 7  // No lambdas nor the APIs of Java 8, for old Android minSdk and desugaring setups
+	javaVersion8       = 8  // Lambdas and the APIs of Java 8, the default
+	javaVersion11      = 11 // As Java 8, the beans stay mutable classes
+	javaVersion17      = 17 // Switch expressions
+	defaultJavaVersion = javaVersion8
+)
+
+// parseJavaVersion validates the javaver parameter
+func (g *Generator) parseJavaVersion(v string) int {
+	n, err := strconv.Atoi(v)
+	if err != nil || (n != javaVersion7 && n != javaVersion8 && n != javaVersion11 && n != javaVersion17) {
+		g.Fail("invalid javaver", v+", use 7, 8, 11 or 17")
+	}
+	return n
+}
+
+// switchExpressions reports whether the beans switch with switch expressions, standard since Java 14
+func (g *Generator) switchExpressions() bool {
+	return g.JavaVersion >= 14
+}
@@ -0,0 +1,29 @@
+package generator
+
+import "strings"
+
+// javaKeywords are the reserved words and literals of java, which cannot name a package, a class or a variable
+var javaKeywords = map[string]bool{
+	"abstract": true, "assert": true, "boolean": true, "break": true, "byte": true, "case": true, "catch": true, "char": true,
+	"class": true, "const": true, "continue": true, "default": true, "do": true, "double": true, "else": true, "enum": true,
+	"extends": true, "final": true, "finally": true, "float": true, "for": true, "goto": true, "if": true, "implements": true,
+	"import": true, "instanceof": true, "int": true, "interface": true, "long": true, "native": true, "new": true, "package": true,
+	"private": true, "protected": true, "public": true, "return": true, "short": true, "static": true, "strictfp": true, "super": true,
+	"switch": true, "synchronized": true, "this": true, "throw": true, "throws": true, "transient": true, "try": true, "void": true,
+	"volatile": true, "while": true, "true": true, "false": true, "null": true, "_": true,
+}
+
+// escapeJavaPackage appends an underscore to the segments of the java package which are java keywords,
+// as protobuf-java escapes the names of fields, e.g. acme.new.internal -> acme.new_.internal
+func escapeJavaPackage(pkg string) string {
+	if pkg == "" {
+		return pkg
+	}
+	segments := strings.Split(pkg, ".")
+	for i, s := range segments {
+		if javaKeywords[s] {
+			segments[i] = s + "_"
+		}
+	}
+	return strings.Join(segments, ".")
+}
@@ -0,0 +1,24 @@
+package generator_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKeywordPackages(t *testing.T) {
+	resp := runFixtures(t, "lang=java,pkgmap=shop.order:com.example.new.order")
+	assertContains(t, resp, map[string][]string{
+		"com/example/new_/order/Order.java": {
+			"package com.example.new_.order;",
+		},
+		"com/example/new_/order/converter/ShopOrderPb2JavaBean.java": {
+			"package com.example.new_.order.converter;",
+			"import com.example.new_.order.Order;",
+		},
+	})
+	for _, f := range resp.File {
+		if strings.Contains(f.GetContent(), "com.example.new.") {
+			t.Errorf("%s refers to the keyword package com.example.new", f.GetName())
+		}
+	}
+}
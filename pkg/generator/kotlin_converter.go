@@ -0,0 +1,260 @@
+package generator
+
+import (
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// kotlinArrayConversions maps primitive kotlin array types to the List extension building them
+var kotlinArrayConversions = map[string]string{
+	"DoubleArray":  "toDoubleArray()",
+	"FloatArray":   "toFloatArray()",
+	"LongArray":    "toLongArray()",
+	"IntArray":     "toIntArray()",
+	"BooleanArray": "toBooleanArray()",
+}
+
+func kotlinPopulateConverter(g *Generator, c *Converter) {
+	populatePreamble(g, "package "+c.Package, c.File)
+	g.beginImports(c.Package, c.Name)
+	g.render("converter", c)
+	g.printImports()
+}
+
+// kotlinPopulateToBean copies the converted fields and the extensions from the protobuf message into the bean
+func kotlinPopulateToBean(g *Generator, file *FileDescriptor, c *JavaClass) {
+	oneofDone := make(map[*JavaOneof]bool)
+	for _, f := range c.Fields {
+		if !f.Converted {
+			continue
+		}
+		if isRealOneof(f.Proto) {
+			if !oneofDone[f.Oneof] {
+				oneofDone[f.Oneof] = true
+				kotlinPopulateOneofToBean(g, file, c, f.Oneof)
+			}
+			continue
+		}
+		kotlinPopulateFieldToBean(g, file, c, f)
+	}
+	kotlinPopulateExtensionsToBean(g, file, c.Desc)
+}
+
+// kotlinPopulateToPb sets the converted fields and the extensions of the bean on the protobuf builder
+func kotlinPopulateToPb(g *Generator, file *FileDescriptor, c *JavaClass) {
+	oneofDone := make(map[*JavaOneof]bool)
+	for _, f := range c.Fields {
+		if !f.Converted {
+			continue
+		}
+		if isRealOneof(f.Proto) {
+			if !oneofDone[f.Oneof] {
+				oneofDone[f.Oneof] = true
+				kotlinPopulateOneofToPb(g, file, c, f.Oneof)
+			}
+			continue
+		}
+		kotlinPopulateFieldToPb(g, file, f)
+	}
+	kotlinPopulateExtensionsToPb(g, file, c.Desc)
+}
+
+func kotlinPopulateOneofToBean(g *Generator, file *FileDescriptor, c *JavaClass, o *JavaOneof) {
+	caseName := oneofCaseName(c.Desc, o.Fields[0].Proto)
+	pbCase := g.pbClassName(c.Desc) + "." + caseName
+
+	g.P("when (pb.get", caseName, "()) {")
+	g.In()
+	for _, f := range o.Fields {
+		if !f.Converted {
+			continue
+		}
+		value := g.converterToBeanValue(file, f.Proto, "pb.get"+javaAccessorName(f.Proto)+"()")
+		g.P(pbCase, ".", f.CaseConstant(), " -> bean.", f.Name, " = ", value)
+	}
+	g.P("else -> {}")
+	g.Out()
+	g.P("}")
+
+	g.P("bean.", o.Name, "Case = ", g.beanRef(c.Desc), ".", o.CaseName(),
+		".forNumber(pb.get", caseName, "().getNumber())")
+}
+
+// kotlinPopulateOneofToPb sets the member of the oneof the case of the bean points to, as the protobuf message
+// holds one member at a time
+func kotlinPopulateOneofToPb(g *Generator, file *FileDescriptor, c *JavaClass, o *JavaOneof) {
+	beanCase := g.beanRef(c.Desc) + "." + o.CaseName()
+
+	g.P("when (bean.", o.Name, "Case) {")
+	g.In()
+	for _, f := range o.Fields {
+		if !f.Converted {
+			continue
+		}
+		g.P(beanCase, ".", f.CaseConstant(), " -> {")
+		g.In()
+		kotlinPopulateFieldToPb(g, file, f)
+		g.Out()
+		g.P("}")
+	}
+	g.P("else -> {}")
+	g.Out()
+	g.P("}")
+}
+
+func kotlinPopulateFieldToBean(g *Generator, file *FileDescriptor, c *JavaClass, f *JavaField) {
+	field := f.Proto
+	name := f.Name
+	accessor := javaAccessorName(field)
+
+	if f.IsMap() {
+		entry := g.mapEntryOf(field)
+		value := g.converterToBeanValue(file, entry.Field[1], "it.value")
+		if value == "it.value" {
+			g.P("bean.", name, " = pb.get", accessor, "Map().toMap()")
+		} else {
+			g.P("bean.", name, " = pb.get", accessor, "Map().mapValues { ", value, " }")
+		}
+		return
+	}
+
+	if isRepeated(field) {
+		value := g.converterToBeanValue(file, field, "it")
+		typeName, _ := kotlinType(field)
+		if conv, ok := kotlinArrayConversions[typeName]; ok {
+			g.P("bean.", name, " = pb.get", accessor, "List().", conv)
+		} else if value == "it" {
+			g.P("bean.", name, " = pb.get", accessor, "List().toList()")
+		} else {
+			g.P("bean.", name, " = pb.get", accessor, "List().map { ", value, " }")
+		}
+		return
+	}
+
+	value := g.converterToBeanValue(file, field, "pb.get"+accessor+"()")
+	switch {
+	case field.GetProto3Optional():
+		g.P("if (pb.has", accessor, "()) {")
+		g.In()
+		g.P("bean.", name, " = ", value)
+		g.P("bean.", f.Oneof.Name, "Case = ", g.beanRef(c.Desc), ".", f.Oneof.CaseName(), ".", f.CaseConstant())
+		g.Out()
+		g.P("}")
+	case isMessage(field), g.hasPresence(c, f):
+		g.P("if (pb.has", accessor, "()) {")
+		g.In()
+		g.P("bean.", name, " = ", value)
+		g.Out()
+		g.P("}")
+	default:
+		g.P("bean.", name, " = ", value)
+	}
+}
+
+// kotlinPopulateExtensionsToBean copies the extensions known in this run from the protobuf message into the bean
+func kotlinPopulateExtensionsToBean(g *Generator, file *FileDescriptor, msg *Descriptor) {
+	for _, ext := range g.extensionsOf(msg) {
+		id := protoJavaExtensionName(ext)
+		key := protoFullName(ext)
+		if isRepeated(ext.FieldDescriptorProto) {
+			g.P("if (pb.getExtensionCount(", id, ") > 0) {")
+			g.In()
+			value := g.converterToBeanValue(file, ext.FieldDescriptorProto, "it")
+			if value == "it" {
+				g.P("bean.", extensionsFieldName, "[\"", key, "\"] = pb.getExtension(", id, ")")
+			} else {
+				g.P("bean.", extensionsFieldName, "[\"", key, "\"] = pb.getExtension(", id, ").map { ", value, " }")
+			}
+		} else {
+			g.P("if (pb.hasExtension(", id, ")) {")
+			g.In()
+			value := g.converterToBeanValue(file, ext.FieldDescriptorProto, "pb.getExtension("+id+")")
+			g.P("bean.", extensionsFieldName, "[\"", key, "\"] = ", value)
+		}
+		g.Out()
+		g.P("}")
+	}
+}
+
+// kotlinPopulateExtensionsToPb sets the extensions known in this run from the bean on the protobuf builder
+func kotlinPopulateExtensionsToPb(g *Generator, file *FileDescriptor, msg *Descriptor) {
+	for _, ext := range g.extensionsOf(msg) {
+		id := protoJavaExtensionName(ext)
+		key := protoFullName(ext)
+		typeName := kotlinValueType(g, g.buildType(ext.FieldDescriptorProto))
+		if isRepeated(ext.FieldDescriptorProto) {
+			values := "values"
+			if value := g.converterToPbValue(file, ext.FieldDescriptorProto, "it"); value != "it" {
+				values = "values.map { " + value + " }"
+			}
+			g.P("(bean.", extensionsFieldName, "[\"", key, "\"] as List<", typeName, ">?)?.let { values -> builder.setExtension(",
+				id, ", ", values, ") }")
+		} else {
+			g.P("(bean.", extensionsFieldName, "[\"", key, "\"] as ", typeName, "?)?.let { builder.setExtension(", id, ", ",
+				g.converterToPbValue(file, ext.FieldDescriptorProto, "it"), ") }")
+		}
+	}
+}
+
+// kotlinPopulateRequiredChecks rejects beans missing a value of a proto2 required field,
+// instead of leaving the failure to the less descriptive check of the protobuf builder
+func kotlinPopulateRequiredChecks(g *Generator, c *JavaClass) {
+	for _, f := range c.Fields {
+		field := f.Proto
+		if !isRequired(field) {
+			continue
+		}
+		if !isMessage(field) &&
+			field.GetType() != descriptor.FieldDescriptorProto_TYPE_ENUM && !g.isBoxedScalar(f) {
+			// never null in the bean
+			continue
+		}
+		g.P("requireNotNull(bean.", f.Name, ") { \"", requiredFieldMessage(c.Desc, field), "\" }")
+	}
+}
+
+func kotlinPopulateFieldToPb(g *Generator, file *FileDescriptor, f *JavaField) {
+	field := f.Proto
+	name := f.Name
+	accessor := javaAccessorName(field)
+
+	if f.IsMap() {
+		entry := g.mapEntryOf(field)
+		value := g.converterToPbValue(file, entry.Field[1], "it.value")
+		if value == "it.value" {
+			g.P("builder.putAll", accessor, "(bean.", name, ")")
+		} else {
+			g.P("builder.putAll", accessor, "(bean.", name, ".mapValues { ", value, " })")
+		}
+		return
+	}
+
+	if isRepeated(field) {
+		value := g.converterToPbValue(file, field, "it")
+		typeName, _ := kotlinType(field)
+		if _, ok := kotlinArrayConversions[typeName]; ok {
+			g.P("builder.addAll", accessor, "(bean.", name, ".asList())")
+		} else if value == "it" {
+			g.P("builder.addAll", accessor, "(bean.", name, ")")
+		} else {
+			g.P("builder.addAll", accessor, "(bean.", name, ".map { ", value, " })")
+		}
+		return
+	}
+
+	nullable := field.OneofIndex != nil ||
+		g.isBoxedScalar(f) ||
+		isMessage(field) ||
+		field.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM
+	if nullable {
+		g.P("bean.", name, "?.let { builder.set", accessor, "(", g.converterToPbValue(file, field, "it"), ") }")
+	} else {
+		g.P("builder.set", accessor, "(", g.converterToPbValue(file, field, "bean."+name), ")")
+	}
+}
+
+func kotlinPopulateTypeRegistry(g *Generator) {
+	populatePreamble(g, "package "+g.registryPackage(), g.genFiles...)
+	g.beginImports(g.registryPackage(), typeRegistryName)
+	g.render("registry", g.registryDescriptors())
+	g.printImports()
+}
@@ -2,428 +2,376 @@ package generator
 
 import (
 	"fmt"
-	"sort"
 	"strings"
-	"time"
+	"text/template"
 
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 )
 
 // TODO: add keyword conversion
 
-// deprecationComment is the standard comment added to deprecated
-// messages, fields, enums, and enum values.
-var deprecationComment = "// Deprecated: Do not use."
-
-func kotlinPopulateHeaderComment(g *Generator, f *FileDescriptor) {
-	g.P()
-	g.P("// Code generated by ", GeneratorName, ". DO NOT EDIT.")
-	if g.NoTime {
-		g.P("// Timestamp generation disabled.")
-	} else {
-		g.P("// ", time.Now().Format("2006-01-02 Mon 15:04:05 UTC-0700"))
-	}
-	g.P("//")
-	g.P("//     ", f.GetName())
-	g.P("//")
-	if f.GetOptions().GetDeprecated() {
-		g.P(deprecationComment)
-	}
-	g.P()
-	g.P()
+// kotlinEnumConstant is the default constant of the bean of an enum
+type kotlinEnumConstant struct {
+	Name      string
+	Number    int32
+	Synthetic bool // Whether the constant is added to the bean, as the enum has no value to default to
 }
 
-func kotlinPopulateEnum(g *Generator, enum *EnumDescriptor) {
-	if enum.parent == nil {
-		g.P("package ", enumPackagePath(g, enum))
-		kotlinPopulateHeaderComment(g, enum.File())
-	}
-
-	if enum.GetOptions().GetDeprecated() {
-		g.P(deprecationComment)
+// kotlinTemplateFuncs returns the functions spelling the model in kotlin for the templates
+func kotlinTemplateFuncs(g *Generator) template.FuncMap {
+	return template.FuncMap{
+		"fieldType": func(f *JavaField) string {
+			typeName, _ := kotlinFieldType(g, f)
+			return typeName
+		},
+		"initialValue": func(f *JavaField) string {
+			_, value := kotlinFieldType(g, f)
+			return value
+		},
+		"valueType": func(t JavaType) string {
+			return kotlinValueType(g, t)
+		},
+		"copies": func(f *JavaField) *kotlinCopies {
+			return kotlinFieldCopies(g, f)
+		},
+		"oneofMember": func(f *JavaField) *oneofMember {
+			return oneofMemberOf(f, "value != null")
+		},
+		"fieldsEnum": func(c *JavaClass) []fieldMetadata {
+			return g.fieldsEnum(c, kotlinPropertyType(g), kotlinClassLiteral, kotlinStringEscape)
+		},
+		"visit": func(c *JavaClass) *beanVisit {
+			return g.beanVisit(c, g.fieldsMetadata(c, kotlinPropertyType(g), kotlinClassLiteral, kotlinStringEscape))
+		},
+		"equals": func(c *JavaClass) *beanEquals {
+			return g.beanEquals(c, kotlinEqualsSyntax)
+		},
+		"diff": func(c *JavaClass) *beanDiff {
+			return g.beanDiff(c, c.Name+"?", kotlinDiffSyntax)
+		},
+		"viewProperties": func(c *JavaClass) []javaProperty {
+			return viewProperties(c, kotlinProperties(g, c))
+		},
+		"mutators": func(c *JavaClass) []collectionMutator {
+			if !g.Mutators {
+				return nil
+			}
+			return collectionMutators(c, func(t JavaType) string { return kotlinValueType(g, t) },
+				func(f *JavaField) (string, string) { return kotlinFieldType(g, f) })
+		},
+		"factories": func(c *JavaClass) *beanFactories {
+			signature := func(params []javaProperty) string {
+				return kotlinFactorySignature(c.Name, params)
+			}
+			params := kotlinProperties(g, c)
+			// declared in the companion of the bean, @JvmStatic keeps the instance method of the companion taking this
+			return g.beanFactories(c, params, 1+parameterSlots(params, "Long", "Double"), signature,
+				(len(c.Desc.TypeName())+1)*len(DefaultIndent))
+		},
+		"defaultValue": func(e *JavaEnum) kotlinEnumConstant {
+			return kotlinEnumDefault(g, e)
+		},
+		"toStringLines": func(c *JavaClass) []string {
+			return g.toStringLines(c, func(f *JavaField) toStringValue { return kotlinToStringValue(g, f) })
+		},
+		"toBean": func(file *FileDescriptor, c *JavaClass) string {
+			return g.capture(func() { kotlinPopulateToBean(g, file, c) })
+		},
+		"toPb": func(file *FileDescriptor, c *JavaClass) string {
+			return g.capture(func() { kotlinPopulateToPb(g, file, c) })
+		},
+		"requiredChecks": func(c *JavaClass) string {
+			return g.capture(func() { kotlinPopulateRequiredChecks(g, c) })
+		},
+		"toMapValue": kotlinToMapValue,
+		"fromMapBody": func(c *JavaClass) string {
+			return g.capture(func() { kotlinPopulateFromMap(g, c) })
+		},
+		"uncheckedFromMap": hasMessageValues,
 	}
+}
 
-	g.PrintComments(enum.path)
-	g.P("enum class ", enum.GetName(), "(var code: Int) {")
+// kotlinDiffSyntax spells the statements of the diff methods in kotlin
+var kotlinDiffSyntax = diffSyntax{
+	Ref: func(f *JavaField, bean string) string {
+		return bean + "." + f.Name
+	},
+	Path: func(name, index string) string {
+		if index == "" {
+			return `"${prefix}` + name + `"`
+		}
+		return `"${prefix}` + name + `[$` + index + `]"`
+	},
+	Size: func(collection string) string {
+		return collection + ".size"
+	},
+	Get: func(collection, index string) string {
+		return collection + "[" + index + "]"
+	},
+	Indices: func(list string) string {
+		return "for (i in " + list + ".indices)"
+	},
+	Keys: func(f *JavaField, m string) string {
+		return "for (key in " + m + ".keys)"
+	},
+	Missing: func(m, key string) string {
+		return key + " !in " + m
+	},
+	Changed: func(f *JavaField, a, b string, element bool) string {
+		if f.Value.Kind == ScalarKind && f.Proto.GetType() == descriptor.FieldDescriptorProto_TYPE_BYTES {
+			return "!" + a + ".contentEquals(" + b + ")"
+		}
+		return a + " != " + b
+	},
+}
 
-	// in order to add default value, need to iterate two rounds
-	addDefaultValue := true
-	defaultName := "Unknown"
-	var defaultValue int32
-	defaultValue = -1
-	for _, e := range enum.Value {
-		low := strings.ToLower(e.GetName())
-		if addDefaultValue && // save some string comparison
-			(strings.Contains(low, "default") ||
-				strings.Contains(low, "unknow") || // the missing 'n' is for poor spelling
-				strings.Contains(low, "invalid")) {
-			addDefaultValue = false
-			defaultName = e.GetName()
-			break
+// kotlinEqualsSyntax spells the statements of the equals and hashCode functions in kotlin
+var kotlinEqualsSyntax = equalsSyntax{
+	Differ: func(f *JavaField, a, b string) string {
+		if f != nil && isByteArray(f.Value) {
+			return "!" + a + ".contentEquals(" + b + ")"
 		}
-		if e.GetNumber() <= defaultValue {
-			defaultValue = e.GetNumber() - 1
+		return a + " != " + b
+	},
+	Hash: func(f *JavaField, value string) string {
+		if f != nil && isByteArray(f.Value) {
+			return value + ".contentHashCode()"
 		}
-	}
+		return value + ".hashCode()"
+	},
+	ListDiffer: func(a, b string) string {
+		return "!" + a + ".toTypedArray().contentDeepEquals(" + b + ".toTypedArray())"
+	},
+	ListHash: func(list string) string {
+		return list + ".toTypedArray().contentDeepHashCode()"
+	},
+	Keys: func(f *JavaField, m string) string {
+		return "for (key in " + m + ".keys)"
+	},
+	Get: func(m, key string) string {
+		return m + "[" + key + "]"
+	},
+	Size: func(collection string) string {
+		return collection + ".size"
+	},
+	KeySet: func(m string) string {
+		return m + ".keys"
+	},
+}
 
-	g.In()
+// kotlinPopulateFile generates a kotlin source file holding the given top-level declarations,
+// kotlin has no one-class-per-file rule so any number of them may share a file.
+func kotlinPopulateFile(g *Generator, thisPackage string, file *FileDescriptor, objs ...Object) {
+	populatePreamble(g, "package "+thisPackage, file)
 
-	if addDefaultValue {
-		g.P(defaultName, "(", &defaultValue, "),")
+	classes := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		classes = append(classes, g.beanName(obj))
 	}
-	for i, e := range enum.Value {
-		etorPath := fmt.Sprintf("%s,%d,%d", enum.path, enumValuePath, i)
-		g.PrintComments(etorPath)
-
-		tails, ok := g.tailingComments(etorPath)
-		if !ok {
-			tails = ""
-		} else {
-			tails = fmt.Sprintf(" %s", tails)
+	g.beginImports(thisPackage, classes...)
+	for _, obj := range objs {
+		if msg, ok := obj.(*Descriptor); ok {
+			g.reserveNested(g.javaClass(msg))
 		}
+	}
 
-		if i == len(enum.Value)-1 {
-			g.P(e.GetName(), "(", e.Number, ");", tails)
-		} else {
-			g.P(e.GetName(), "(", e.Number, "),", tails)
+	for i, obj := range objs {
+		if i > 0 {
+			g.P()
+		}
+		switch o := obj.(type) {
+		case *Descriptor:
+			g.render("bean", g.javaClass(o))
+		case *EnumDescriptor:
+			g.render("enum", g.javaEnum(o))
 		}
 	}
-	g.Newline()
-	g.P("companion object {")
-	g.In()
-	g.P("fun forNumber(value: Int): ", enum.GetName(), " {")
-	g.In()
-	g.P("return when (value) {")
-	g.In()
-	for _, e := range enum.Value {
-		g.P(e.GetName(), ".code -> ", e.GetName())
-	}
-	g.P("else -> ", defaultName)
-	g.Out()
-	g.P("}")
-	g.Out()
-	g.P("}")
-	g.Out()
-	g.P("}")
-
-	g.Out()
-	g.P("}")
+	g.printImports()
 }
 
-func getFieldTypeName(g *Generator, field *descriptor.FieldDescriptorProto) string {
-	obj, ok := g.typeNameToObject[field.GetTypeName()]
-	if !ok {
-		g.Fail("unable to find object with type named,", field.GetTypeName())
-	}
-	// .package.name.TypeName -> package.name.TypeName
-	typeName := field.GetTypeName()[1:]
-	// package.name.TypeName -> TypeName
-	typeName = strings.TrimPrefix(typeName, obj.File().GetPackage())[1:]
+// kotlinPopulateTypeAliases generates the type aliases re-exporting the publicly imported beans in the package
+func kotlinPopulateTypeAliases(g *Generator, thisPackage string, file *FileDescriptor, imps []*ImportedDescriptor) {
+	populatePreamble(g, "package "+thisPackage, file)
 
-	return typeName
+	for i, imp := range imps {
+		if i > 0 {
+			g.P()
+		}
+		g.P("// ", protoFullName(imp.o), " publicly imported from ", imp.o.File().GetName())
+		g.P("typealias ", g.beanName(imp.o), " = ", strings.Join(getFullPathComponents(g, imp.o.File(), g.beanTypeName(imp.o)), "."))
+	}
 }
 
-func kotlinExtractImports(g *Generator, msg *Descriptor, sysImp, usrImp map[string]string) {
-	// nested descriptor's fields
-	for _, nested := range msg.nested {
-		kotlinExtractImports(g, nested, sysImp, usrImp)
+// kotlinEnumDefault returns the default constant of the enum, set explicitly, the value numbered zero as protobuf
+// defaults to, or guessed from the names of the values. Without a value to default to, a constant of an unused
+// number is added, with a warning.
+func kotlinEnumDefault(g *Generator, e *JavaEnum) kotlinEnumConstant {
+	if e.Default >= 0 {
+		return kotlinEnumConstant{Name: e.Values[e.Default].Name}
 	}
-
-	for _, field := range msg.Field {
-		switch field.GetType() {
-		case descriptor.FieldDescriptorProto_TYPE_ENUM:
-			fallthrough
-		case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-			obj, ok := g.typeNameToObject[field.GetTypeName()]
-			if !ok {
-				g.Fail("unable to find object with type named,", field.GetTypeName())
-			}
-			// .package.name.TypeName -> package.name.TypeName
-			typeName := field.GetTypeName()[1:]
-			// package.name.TypeName -> TypeName
-			typeName = strings.TrimPrefix(typeName, obj.File().GetPackage())[1:]
-
-			// RootMsg.NestMsg -> RootMsg
-			importPkg := typeName
-			if strings.Contains(typeName, ".") {
-				importPkg = strings.Split(typeName, ".")[0]
-			}
-
-			fullJavaImportPath := fmt.Sprintf("%s.%s", obj.JavaImportPath().String(), importPkg)
-			usrImp[fullJavaImportPath] = typeName
+	for _, v := range e.Values {
+		if v.Number == 0 {
+			return kotlinEnumConstant{Name: v.Name}
 		}
 	}
+	def := kotlinEnumConstant{Name: "Unknown", Number: -1, Synthetic: true}
+	for _, v := range e.Values {
+		low := strings.ToLower(v.Name)
+		if strings.Contains(low, "default") ||
+			strings.Contains(low, "unknow") || // the missing 'n' is for poor spelling
+			strings.Contains(low, "invalid") {
+			return kotlinEnumConstant{Name: v.Name}
+		}
+		if v.Number <= def.Number {
+			def.Number = v.Number - 1
+		}
+	}
+	g.Warn(warnEnumDefault, "enum", protoFullName(e.Desc), "has no default constant, added", def.Name+", set one with the default option")
+	return def
 }
 
-func kotlinPopulateField(g *Generator, msg *Descriptor, field *descriptor.FieldDescriptorProto, index int) {
-	typeName := ""
-	typeDefaultValue := ""
+// kotlinValueType returns the kotlin type of a single value, e.g. of the elements of a list
+func kotlinValueType(g *Generator, t JavaType) string {
+	switch t.Kind {
+	case EnumKind, MessageKind:
+		return g.beanRef(t.Object)
+	case AnyKind:
+		return "Any"
+	case CustomKind:
+		return t.Class
+	}
+	typeName, _ := kotlinType(&descriptor.FieldDescriptorProto{Type: t.Proto.Enum()})
+	return typeName
+}
 
-	switch field.GetType() {
-	case descriptor.FieldDescriptorProto_TYPE_ENUM:
-		fallthrough
-	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-		desc := g.ObjectNamed(field.GetTypeName())
-		if d, ok := desc.(*Descriptor); ok && d.GetOptions().GetMapEntry() {
-			// Figure out the kotlin types and tags for the key and value type
-			keyField, valField := d.Field[0], d.Field[1]
-			typeName, typeDefaultValue = kotlinPopulateMap(g, keyField, valField)
-		} else {
-			typeName = getFieldTypeName(g, field)
-			if isRepeated(field) {
-				typeName = fmt.Sprintf("List<%s>", typeName)
-				typeDefaultValue = "emptyList()"
-			} else {
-				typeName = fmt.Sprintf("%s?", typeName)
-				typeDefaultValue = "null"
-			}
-		}
-	default:
-		typeName, typeDefaultValue = kotlinType(field)
+// kotlinFieldType returns the kotlin type and the initial value of the bean property
+func kotlinFieldType(g *Generator, f *JavaField) (typeName, typeDefaultValue string) {
+	switch {
+	case f.Value.Kind == CustomKind:
+		typeName, typeDefaultValue = f.Value.Class+"?", "null"
+	case f.IsMap():
+		typeName = fmt.Sprintf("Map<%s, %s>", kotlinValueType(g, *f.Key), kotlinValueType(g, f.Value))
+		typeDefaultValue = "mapOf()"
+	case f.Value.Kind == ScalarKind:
+		typeName, typeDefaultValue = kotlinType(f.Proto)
 		if typeName == "" {
-			g.Fail("unknown type for", field.GetName())
+			g.Fail("unsupported type", f.Proto.GetType().String(), "of field", f.Proto.GetName())
+		}
+		if hasScalarDefault(f.Proto) {
+			typeDefaultValue = kotlinScalarDefault(f.Proto)
 		}
+	case f.Repeated:
+		typeName = fmt.Sprintf("List<%s>", kotlinValueType(g, f.Value))
+		typeDefaultValue = "emptyList()"
+	default:
+		typeName = fmt.Sprintf("%s?", kotlinValueType(g, f.Value))
+		typeDefaultValue = "null"
 	}
 
-	if field.OneofIndex != nil {
-		// oneof
+	if f.Oneof != nil || g.isBoxedScalar(f) {
+		// members of oneofs and boxed scalars are null until set
 		if !strings.HasSuffix(typeName, "?") {
 			typeName = fmt.Sprintf("%v?", typeName)
 		}
 		typeDefaultValue = "null"
 	}
-
-	ftorPath := fmt.Sprintf("%s,%d,%d", msg.path, messageFieldPath, index)
-	if c, ok := g.makeComments(ftorPath); ok {
-		g.Newline()
-		g.P(c)
-	}
-	//g.PrintComments(ftorPath)
-	tail, ok := g.tailingComments(ftorPath)
-	if !ok {
-		tail = ""
-	} else {
-		tail = fmt.Sprintf(" %s", tail)
-	}
-	g.P("var ", javaFieldName(field), ": ", typeName, " = ", typeDefaultValue, tail)
-}
-
-func kotlinPopulateMap(g *Generator, keyField, valField *descriptor.FieldDescriptorProto) (typeName, typeDefaultValue string) {
-	var keyTypeName string
-	switch keyField.GetType() {
-	case descriptor.FieldDescriptorProto_TYPE_ENUM:
-		fallthrough
-	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-		keyTypeName = getFieldTypeName(g, keyField)
-	default:
-		keyTypeName, _ = kotlinType(keyField)
-	}
-
-	var valTypeName string
-	switch valField.GetType() {
-	case descriptor.FieldDescriptorProto_TYPE_ENUM:
-		fallthrough
-	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-		valTypeName = getFieldTypeName(g, valField)
-	default:
-		valTypeName, _ = kotlinType(valField)
-	}
-
-	typeName = fmt.Sprintf("Map<%s, %s>", keyTypeName, valTypeName)
-	typeDefaultValue = "mapOf()"
-
 	return
 }
 
-func kotlinPopulateToString(g *Generator, msg *Descriptor) {
-	g.P("override fun toString(): String {")
-	g.In()
-	g.P("return \"", msg.GetName(), "{\" +")
-	g.In()
-	g.In()
-
-	sb := &strings.Builder{}
-
-	for i, field := range msg.Field {
-		name := javaFieldName(field)
-		repeat := isRepeated(field)
-
-		sb.Reset()
-		sb.WriteByte('"')
-		if i != 0 {
-			sb.WriteString(", ")
-		}
-
-		sb.WriteString(name)
-
-		sb.WriteByte('=')
-		if field.GetType() == descriptor.FieldDescriptorProto_TYPE_STRING && !repeat {
-			sb.WriteByte('\'')
-		}
-
-		sb.WriteString("\" + ")
-
-		switch field.GetType() {
-		case descriptor.FieldDescriptorProto_TYPE_BYTES:
-			sb.WriteString(fmt.Sprintf("%s.size + \" bytes\"", name))
-		case descriptor.FieldDescriptorProto_TYPE_STRING:
-			sb.WriteString(name)
-			if !repeat {
-				sb.WriteString(" + '\\''")
-			}
-		case descriptor.FieldDescriptorProto_TYPE_ENUM:
-			sb.WriteString(name)
-		case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-			sb.WriteString(name)
-		default:
-			if repeat {
-				sb.WriteString(fmt.Sprintf("%s.contentToString()", name))
-			} else {
-				sb.WriteString(name)
-			}
-		}
-
-		sb.WriteString(" +")
-
-		g.P(sb.String())
+// kotlinPropertyType returns the function returning the kotlin type of the property of a field
+func kotlinPropertyType(g *Generator) func(f *JavaField) string {
+	return func(f *JavaField) string {
+		typeName, _ := kotlinFieldType(g, f)
+		return typeName
 	}
-	g.P("\"}\"")
-	g.Out()
-	g.Out()
-	g.Out()
-	g.P("}")
 }
 
-func underSamePackage(importTypeFullpath, myPackage string) bool {
-	importComponents := strings.Split(importTypeFullpath, ".")
-	if len(importComponents) > 1 {
-		importComponents = importComponents[:len(importComponents)-1]
-	}
-	importPackage := strings.Join(importComponents, ".")
-	return strings.Compare(importPackage, myPackage) == 0
+// kotlinClassLiteral returns the class literal of the type without arguments, e.g. List::class
+func kotlinClassLiteral(raw string) string {
+	return strings.TrimSuffix(raw, "?") + "::class"
 }
 
-func kotlinPopulateDescriptor(g *Generator, msg *Descriptor) {
-	// only root messages have package announcement, header and imports
-	if msg.parent == nil {
-		// only root messages have these fancy stuff
-		thisPackage := descriptorPackagePath(g, msg)
-		g.P("package ", thisPackage)
-		kotlinPopulateHeaderComment(g, msg.File())
-
-		// imports
-		sysImp := make(map[string]string)
-		usrImp := make(map[string]string)
-		kotlinExtractImports(g, msg, sysImp, usrImp)
-
-		if len(usrImp) > 0 {
-			usrImpKeys := make([]string, 0, len(usrImp))
-			for importPath := range usrImp {
-				usrImpKeys = append(usrImpKeys, importPath)
-			}
-			sort.Strings(usrImpKeys)
-			addParagraph := false
-			for _, p := range usrImpKeys {
-				if !underSamePackage(p, thisPackage) {
-					g.P("import ", p)
-					addParagraph = true
-				}
-			}
-			if addParagraph {
-				g.P()
-			}
-		}
+// kotlinCopies are the accessors of a property copying its value, with defensive_copies=true
+type kotlinCopies struct {
+	Get string // Expression returned by the getter, empty for read-only collections which need no copy
+	Set string // Expression assigned by the setter
+}
 
-		if len(sysImp) > 0 {
-			sysImpKeys := make([]string, 0, len(sysImp))
-			for importPath := range sysImp {
-				sysImpKeys = append(sysImpKeys, importPath)
-			}
-			sort.Strings(sysImpKeys)
-			for _, p := range sysImpKeys {
-				g.P("import ", p)
-			}
-			g.P()
-		}
+// kotlinFieldCopies returns the accessors of the property copying lists, maps and arrays, nil when it needs none
+func kotlinFieldCopies(g *Generator, f *JavaField) *kotlinCopies {
+	if !g.DefensiveCopies || f.Value.Kind == CustomKind {
+		return nil
 	}
-
-	if msg.GetOptions().GetDeprecated() {
-		g.P(deprecationComment)
+	typeName, _ := kotlinFieldType(g, f)
+	call := "."
+	if strings.HasSuffix(typeName, "?") {
+		call = "?."
 	}
-
-	g.PrintComments(msg.path)
-	g.P("class ", msg.GetName(), " {")
-	g.In()
-
-	// fields
-	oFields := make(map[int32]*oneofField)
-
-	for i, field := range msg.Field {
-		oneof := field.OneofIndex != nil
-		if oneof && oFields[*field.OneofIndex] == nil {
-			odp := msg.OneofDecl[int(*field.OneofIndex)]
-
-			of := oneofField{
-				name:      CamelCase(odp.GetName()),
-				field:     field,
-				subFields: nil,
-			}
-
-			oFields[*field.OneofIndex] = &of
-		}
-
-		if oneof {
-			sf := oneofSubField{
-				index: i,
-				field: field,
-			}
-
-			of := oFields[*field.OneofIndex]
-			if of != nil {
-				of.subFields = append(of.subFields, &sf)
-			}
-		}
-
-		kotlinPopulateField(g, msg, field, i)
+	switch {
+	case f.IsMap():
+		return &kotlinCopies{Set: g.AddImport("java.util.Collections") + ".unmodifiableMap(value.toMap())"}
+	case strings.HasPrefix(typeName, "List<"):
+		// read-only to kotlin callers, unmodifiable to java ones
+		return &kotlinCopies{Set: g.AddImport("java.util.Collections") + ".unmodifiableList(value.toList())"}
+	case strings.HasSuffix(strings.TrimSuffix(typeName, "?"), "Array"):
+		return &kotlinCopies{Get: "field" + call + "copyOf()", Set: "value" + call + "copyOf()"}
 	}
-	g.Out()
-
-	// oneof
-	for _, of := range oFields {
-		g.P()
-		g.In()
-
-		of.populate(g, msg)
+	return nil
+}
 
-		g.Out()
+// kotlinProperties returns the properties of the bean as it declares them,
+// the fields followed by the cases of the oneofs and the extension values
+func kotlinProperties(g *Generator, c *JavaClass) []javaProperty {
+	props := make([]javaProperty, 0, len(c.Fields)+len(c.Oneofs)+1)
+	for _, f := range c.Fields {
+		typeName, _ := kotlinFieldType(g, f)
+		props = append(props, javaProperty{Type: typeName, Name: f.Name})
 	}
-
-	// nested enums
-	for _, nestEnum := range msg.enums {
-		g.P()
-		g.In()
-		kotlinPopulateEnum(g, nestEnum)
-		g.Out()
+	for _, o := range c.Oneofs {
+		props = append(props, javaProperty{Type: o.CaseName(), Name: o.Name + "Case"})
+	}
+	if c.Extendable {
+		props = append(props, javaProperty{Type: "MutableMap<String, Any>", Name: "extensions"})
 	}
+	return props
+}
 
-	// nested descriptors
-	for _, nestDesc := range msg.nested {
-		if nestDesc.GetOptions().GetMapEntry() {
-			// Don't generate virtual messages for maps.
-			continue
-		}
-		g.P()
-		g.In()
-		kotlinPopulateDescriptor(g, nestDesc)
-		g.Out()
+// kotlinFactorySignature returns the signature of the of factory on a single line
+func kotlinFactorySignature(class string, params []javaProperty) string {
+	decls := make([]string, len(params))
+	for i, p := range params {
+		decls[i] = p.Name + ": " + p.Type
 	}
+	return "fun of(" + strings.Join(decls, ", ") + "): " + class + " {"
+}
 
-	if len(msg.Field) > 0 {
-		g.P()
-		g.In()
-		kotlinPopulateToString(g, msg)
+// kotlinScalarDefault returns the literal of the proto2 default of a string or bytes field
+func kotlinScalarDefault(field *descriptor.FieldDescriptorProto) string {
+	if field.GetType() == descriptor.FieldDescriptorProto_TYPE_BYTES {
+		return "byteArrayOf(" + byteValues(field.GetDefaultValue()) + ")"
 	}
+	return `"` + kotlinStringEscape(field.GetDefaultValue()) + `"`
+}
 
-	g.Out()
-	g.P("}")
+// kotlinToStringValue returns how toString prints the value of the property, following its label
+func kotlinToStringValue(g *Generator, f *JavaField) toStringValue {
+	v := toStringValue{Label: kotlinStringEscape(f.Label)}
+	ref := g.toStringRef(f)
+	switch {
+	case f.Redacted:
+	case f.Value.Kind == CustomKind:
+		// printed by its own toString, whatever the proto type
+		v.Expr = ref
+	case f.Proto.GetType() == descriptor.FieldDescriptorProto_TYPE_BYTES && g.isNullScalar(f):
+		v.Expr, v.Suffix = ref+"?.size", " bytes"
+	case f.Proto.GetType() == descriptor.FieldDescriptorProto_TYPE_BYTES:
+		v.Expr, v.Suffix = ref+".size", " bytes"
+	case f.Proto.GetType() == descriptor.FieldDescriptorProto_TYPE_STRING && !f.Repeated:
+		v.Expr, v.Quote = ref, true
+	case f.Value.Kind == ScalarKind && f.Repeated && !f.IsMap() && f.Proto.GetType() != descriptor.FieldDescriptorProto_TYPE_STRING:
+		v.Expr = ref + ".contentToString()"
+	default:
+		v.Expr = ref
+	}
+	return v
 }
@@ -0,0 +1,65 @@
+package generator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/master-g/protoc-gen-bean/pkg/generator"
+)
+
+// enumRequest returns a request of enums.proto, a proto2 file declaring an enum of the values and numbers
+func enumRequest(parameter string, values ...interface{}) *plugin.CodeGeneratorRequest {
+	enum := &descriptor.EnumDescriptorProto{Name: proto.String("Level")}
+	for i := 0; i < len(values); i += 2 {
+		enum.Value = append(enum.Value, &descriptor.EnumValueDescriptorProto{
+			Name:   proto.String(values[i].(string)),
+			Number: proto.Int32(int32(values[i+1].(int))),
+		})
+	}
+	return &plugin.CodeGeneratorRequest{
+		ProtoFile: []*descriptor.FileDescriptorProto{{
+			Name:     proto.String("enums.proto"),
+			Package:  proto.String("example.enums"),
+			Syntax:   proto.String("proto2"),
+			EnumType: []*descriptor.EnumDescriptorProto{enum},
+		}},
+		FileToGenerate: []string{"enums.proto"},
+		Parameter:      proto.String(parameter),
+	}
+}
+
+// TestKotlinEnumDefault checks the value numbered zero is the default constant of an enum without one set,
+// an Unknown constant being added with a warning only when the enum has no such value
+func TestKotlinEnumDefault(t *testing.T) {
+	tests := []struct {
+		values []interface{}
+		want   string
+		warned bool
+	}{
+		{[]interface{}{"HIGH", 2, "NONE", 0}, "else -> NONE", false},
+		{[]interface{}{"HIGH", 2, "INVALID_LEVEL", 1}, "else -> INVALID_LEVEL", false},
+		{[]interface{}{"HIGH", 2, "LOW", 1}, "Unknown(-1, \"\")", true},
+	}
+	for _, tt := range tests {
+		resp, err := generator.Run(enumRequest("strict=false", tt.values...), generator.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var enum string
+		for _, f := range resp.File {
+			if strings.HasSuffix(f.GetName(), "/Level.kt") {
+				enum = f.GetContent()
+			}
+		}
+		if !strings.Contains(enum, tt.want) {
+			t.Errorf("%v: got no %s in\n%s", tt.values, tt.want, enum)
+		}
+		_, err = generator.Run(enumRequest("strict=true", tt.values...), generator.Options{})
+		if warned := err != nil && strings.Contains(err.Error(), "has no default constant"); warned != tt.warned {
+			t.Errorf("%v: warned %t, want %t", tt.values, warned, tt.warned)
+		}
+	}
+}
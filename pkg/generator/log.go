@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+)
+
+// Categories of warnings
+const (
+	warnParameter   = "parameter"
+	warnEnumDefault = "enum default"
+	warnDirective   = "directive"
+	warnDeprecated  = "deprecated"
+	warnConstructor = "constructor"
+	warnCompiler    = "compiler"
+	warnPackage     = "package"
+)
+
+// warning is a non-fatal problem found during the generation
+type warning struct {
+	category string
+	msg      string
+}
+
+// newLogger returns the logger of the diagnostics written to w,
+// the plugin writes them to stderr as stdout carries the response to protoc
+func newLogger(w io.Writer) *log.Logger {
+	return log.New(w, GeneratorName+": ", 0)
+}
+
+// Infof logs a message unless quiet=true
+func (g *Generator) Infof(format string, args ...interface{}) {
+	if g.Quiet {
+		return
+	}
+	g.logger.Printf(format, args...)
+}
+
+// Debugf logs a message only with verbose=true
+func (g *Generator) Debugf(format string, args ...interface{}) {
+	if !g.Verbose {
+		return
+	}
+	g.logger.Printf(format, args...)
+}
+
+// logFiles logs the summary of the output generated from a proto file, starting at the index of the response files
+func (g *Generator) logFiles(name string, from int) {
+	files := g.outputFiles[from:]
+	for _, f := range files {
+		g.Debugf("wrote %s (%d lines)", f.name, f.lines)
+	}
+	g.Infof("%s: generated %d file(s)", name, len(files))
+}
+
+// logServices notes the services of the file, which are not generated, beans are made of messages and enums only
+func (g *Generator) logServices(file *FileDescriptor) {
+	names := make([]string, 0, len(file.Service))
+	for _, svc := range file.Service {
+		names = append(names, svc.GetName())
+	}
+	g.Infof("%s: services ignored: %s", file.GetName(), strings.Join(names, ", "))
+}
+
+// Warn records a non-fatal problem, warnings are reported together at the end of the generation
+func (g *Generator) Warn(category string, msgs ...string) {
+	msg := strings.Join(msgs, " ")
+	if g.file != nil {
+		msg = g.file.GetName() + ": " + msg
+	}
+	g.warnings = append(g.warnings, warning{category, msg})
+}
+
+// logWarnings logs the recorded warnings grouped by category
+func (g *Generator) logWarnings() {
+	if len(g.warnings) == 0 {
+		return
+	}
+	groups := make(map[string][]string)
+	for _, w := range g.warnings {
+		groups[w.category] = append(groups[w.category], w.msg)
+	}
+	categories := make([]string, 0, len(groups))
+	for c := range groups {
+		categories = append(categories, c)
+	}
+	sort.Strings(categories)
+
+	g.Infof("%d warning(s)", len(g.warnings))
+	for _, c := range categories {
+		g.Infof("  %s:", c)
+		for _, msg := range groups[c] {
+			g.Infof("    %s", msg)
+		}
+	}
+}
+
+// failOnWarnings aborts the generation with every recorded warning, for strict=true
+func (g *Generator) failOnWarnings() {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d warning(s) with strict=true", len(g.warnings))
+	for _, w := range g.warnings {
+		fmt.Fprintf(&b, "\n  %s: %s", w.category, w.msg)
+	}
+	g.file = nil
+	g.Fail(b.String())
+}
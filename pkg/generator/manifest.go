@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// manifestFileName is the name of the response file listing the generated files
+const manifestFileName = "bean-manifest.json"
+
+// outputFile is a generated file along with what it is generated from,
+// its content is passed on once complete, see emit, only its digest is kept for the manifest
+type outputFile struct {
+	name    string
+	lines   int
+	sha256  string
+	sources []*FileDescriptor
+	types   []Object
+}
+
+// manifestEntry describes a generated file in the manifest
+type manifestEntry struct {
+	Path    string   `json:"path"`
+	Sources []string `json:"sources"`
+	Types   []string `json:"types"`
+	SHA256  string   `json:"sha256"`
+}
+
+// addOutputFile adds the formatted content of the buffer to the output as the named file,
+// generated from the messages and enums of the source files.
+func (g *Generator) addOutputFile(name string, sources []*FileDescriptor, types []Object) {
+	g.addFile(name, formatSource(g.String(), g.LineEnding), sources, types)
+}
+
+// addFile records the generated file and passes it on to the output
+func (g *Generator) addFile(name, content string, sources []*FileDescriptor, types []Object) {
+	name = g.checkOutputPath(name)
+	o := &outputFile{name: name, lines: strings.Count(content, "\n"), sources: sources, types: types}
+	g.checkDuplicate(o)
+	if g.Manifest {
+		sum := sha256.Sum256([]byte(content))
+		o.sha256 = hex.EncodeToString(sum[:])
+	}
+	g.outputFiles = append(g.outputFiles, o)
+	g.emit(&plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(name),
+		Content: proto.String(content),
+	})
+}
+
+// checkDuplicate fails when a file of the same name is already generated, naming the protos both are generated from,
+// protoc would otherwise keep whichever came last
+func (g *Generator) checkDuplicate(o *outputFile) {
+	if prev, ok := g.outputNames[o.name]; ok {
+		g.Fail("duplicate output file", o.name+", generated from", sourceNames(prev.sources), "and from", sourceNames(o.sources))
+	}
+	if g.outputNames == nil {
+		g.outputNames = make(map[string]*outputFile)
+	}
+	g.outputNames[o.name] = o
+}
+
+// sourceNames lists the names of the proto files for a message
+func sourceNames(files []*FileDescriptor) string {
+	if len(files) == 0 {
+		return "the generator"
+	}
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, f.GetName())
+	}
+	return strings.Join(names, ", ")
+}
+
+// emit passes the complete file on, into the archive when one is built, to the stream when one is set,
+// or into the response, so that with a stream memory scales with the largest file rather than the whole output
+func (g *Generator) emit(f *plugin.CodeGeneratorResponse_File) {
+	switch {
+	case g.archive != nil:
+		g.archiveFile(f)
+	case g.stream != nil:
+		if err := g.stream(f); err != nil {
+			g.Error(err, "failed to write", f.GetName())
+		}
+	default:
+		g.Response.File = append(g.Response.File, f)
+	}
+}
+
+// generateManifest adds the manifest of the generated files to the output
+func (g *Generator) generateManifest() {
+	entries := make([]manifestEntry, 0, len(g.outputFiles))
+	for _, o := range g.outputFiles {
+		e := manifestEntry{
+			Path:    o.name,
+			Sources: make([]string, 0, len(o.sources)),
+			Types:   make([]string, 0, len(o.types)),
+		}
+		for _, f := range o.sources {
+			e.Sources = append(e.Sources, f.GetName())
+		}
+		for _, obj := range o.types {
+			e.Types = append(e.Types, protoFullName(obj))
+		}
+		e.SHA256 = o.sha256
+		entries = append(entries, e)
+	}
+
+	data, err := json.MarshalIndent(struct {
+		Generator string          `json:"generator"`
+		Files     []manifestEntry `json:"files"`
+	}{GeneratorName + " " + g.Version, entries}, "", "  ")
+	if err != nil {
+		g.Error(err, "failed to marshal manifest")
+	}
+	g.checkDuplicate(&outputFile{name: manifestFileName})
+	g.emit(&plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(manifestFileName),
+		Content: proto.String(string(data) + "\n"),
+	})
+}
@@ -0,0 +1,272 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// The toMap and fromMap methods of to_map=true hold the properties of the beans in maps keyed by the names of
+// their fields: beans of messages become maps of their own, enums become the names of their constants, lists and
+// maps are copied and numbers are read back from any java.lang.Number, as loosely-typed stores hand them out.
+
+// mapLocal returns the name of the local variable holding the collection of the property,
+// named apart from the other locals of the toMap and fromMap methods
+func mapLocal(f *JavaField) string {
+	name := f.Name
+	for name == "map" || name == "bean" || name == "v" || name == "e" {
+		name += "_"
+	}
+	return name
+}
+
+// hasMessageValues reports whether fromMap casts the maps of nested messages, unchecked
+func hasMessageValues(c *JavaClass) bool {
+	for _, f := range c.Fields {
+		if f.Value.Kind == MessageKind {
+			return true
+		}
+	}
+	return false
+}
+
+// numberType returns the primitive java type of the number, int for the 32-bit integers and booleans excluded
+func numberType(t descriptor.FieldDescriptorProto_Type) string {
+	switch t {
+	case descriptor.FieldDescriptorProto_TYPE_DOUBLE:
+		return "double"
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		return "float"
+	case descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_UINT64,
+		descriptor.FieldDescriptorProto_TYPE_SINT64,
+		descriptor.FieldDescriptorProto_TYPE_FIXED64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		return "long"
+	}
+	return "int"
+}
+
+// javaMapValueType returns the type of the values of the maps returned by toMap, which may be null
+func javaMapValueType(g *Generator) string {
+	if g.Nullability == nullabilityJSpecify {
+		return g.nullableType("Object")
+	}
+	return "Object"
+}
+
+// javaToMapValue returns the map value of a single value of the field, which may be null unless an element
+func javaToMapValue(f *JavaField, value string, element bool) string {
+	switch f.Value.Kind {
+	case MessageKind:
+		if element {
+			return value + ".toMap()"
+		}
+		return value + " == null ? null : " + value + ".toMap()"
+	case EnumKind:
+		if element {
+			return value + ".name()"
+		}
+		return value + " == null ? null : " + value + ".name()"
+	}
+	return value
+}
+
+// javaFromMapValue returns a single value of the type read from the map value, which is not null
+func javaFromMapValue(g *Generator, t JavaType, value string) string {
+	switch t.Kind {
+	case MessageKind:
+		return g.beanRef(t.Object) + ".fromMap((" + g.AddImport("java.util.Map") + "<String, ?>) " + value + ")"
+	case EnumKind:
+		return g.beanRef(t.Object) + ".valueOf((String) " + value + ")"
+	case AnyKind:
+		return value
+	}
+	switch t.Proto {
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return "(String) " + value
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return "(byte[]) " + value
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return "(Boolean) " + value
+	}
+	return "((Number) " + value + ")." + numberType(t.Proto) + "Value()"
+}
+
+// javaPopulateToMap puts the properties of the bean into the map
+func javaPopulateToMap(g *Generator, c *JavaClass) {
+	g.P(g.AddImport("java.util.Map"), "<String, ", javaMapValueType(g), "> map = new ", g.AddImport("java.util.LinkedHashMap"), "<>();")
+	for _, f := range c.Fields {
+		key := `"` + javaStringEscape(f.Proto.GetName()) + `"`
+		ref := "this." + f.Name
+		converted := f.Value.Kind == MessageKind || f.Value.Kind == EnumKind
+		switch {
+		case f.Value.Kind == CustomKind:
+			g.P("map.put(", key, ", ", ref, ");")
+		case g.isOptionalMessage(f):
+			g.P("map.put(", key, ", ", ref, ".isPresent() ? ", ref, ".get().toMap() : null);")
+		case f.IsMap() && converted:
+			local := mapLocal(f)
+			g.P(g.AddImport("java.util.Map"), "<Object, Object> ", local, " = new ", g.AddImport("java.util.LinkedHashMap"), "<>();")
+			g.P("for (", g.AddImport("java.util.Map"), ".Entry<", javaValueType(g, *f.Key), ", ", javaValueType(g, f.Value), "> e : ",
+				ref, ".entrySet()) {")
+			g.In()
+			g.P(local, ".put(e.getKey(), ", javaToMapValue(f, "e.getValue()", true), ");")
+			g.Out()
+			g.P("}")
+			g.P("map.put(", key, ", ", local, ");")
+		case f.IsMap():
+			g.P("map.put(", key, ", new ", g.AddImport("java.util.LinkedHashMap"), "<>(", ref, "));")
+		case f.Repeated && converted:
+			local := mapLocal(f)
+			g.P(g.AddImport("java.util.List"), "<Object> ", local, " = new ", g.AddImport("java.util.ArrayList"), "<>();")
+			g.P("for (", javaValueType(g, f.Value), " v : ", ref, ") {")
+			g.In()
+			g.P(local, ".add(", javaToMapValue(f, "v", true), ");")
+			g.Out()
+			g.P("}")
+			g.P("map.put(", key, ", ", local, ");")
+		case f.Repeated:
+			g.P("map.put(", key, ", new ", g.AddImport("java.util.ArrayList"), "<>(", ref, "));")
+		default:
+			g.P("map.put(", key, ", ", javaToMapValue(f, ref, false), ");")
+		}
+	}
+	g.P("return map;")
+}
+
+// javaPopulateFromMap sets the properties of the bean from the values of the map which are not null,
+// the case of a oneof along with its member
+func javaPopulateFromMap(g *Generator, c *JavaClass) {
+	bean := g.beanRef(c.Desc)
+	g.P(c.Name, " bean = new ", c.Name, "();")
+	for _, f := range c.Fields {
+		value := `map.get("` + javaStringEscape(f.Proto.GetName()) + `")`
+		setter := "bean." + javaSetterName(f.Name)
+		g.P("if (", value, " != null) {")
+		g.In()
+		switch {
+		case f.Value.Kind == CustomKind:
+			g.P(setter, "((", f.Value.Class, ") ", value, ");")
+		case f.IsMap():
+			local := mapLocal(f)
+			g.P(g.AddImport("java.util.Map"), "<", javaValueType(g, *f.Key), ", ", javaValueType(g, f.Value), "> ", local,
+				" = new ", g.AddImport("java.util.HashMap"), "<>();")
+			g.P("for (", g.AddImport("java.util.Map"), ".Entry<?, ?> e : ((", g.AddImport("java.util.Map"), "<?, ?>) ", value, ").entrySet()) {")
+			g.In()
+			g.P(local, ".put(", javaFromMapValue(g, *f.Key, "e.getKey()"), ", ", javaFromMapValue(g, f.Value, "e.getValue()"), ");")
+			g.Out()
+			g.P("}")
+			if g.Guava {
+				local = g.AddImport(guavaImmutableMap) + ".copyOf(" + local + ")"
+			}
+			g.P(setter, "(", local, ");")
+		case f.Repeated:
+			local := mapLocal(f)
+			g.P(g.AddImport("java.util.List"), "<", javaValueType(g, f.Value), "> ", local, " = new ", g.AddImport("java.util.ArrayList"), "<>();")
+			g.P("for (Object v : (", g.AddImport("java.util.List"), "<?>) ", value, ") {")
+			g.In()
+			g.P(local, ".add(", javaFromMapValue(g, f.Value, "v"), ");")
+			g.Out()
+			g.P("}")
+			if g.Guava {
+				local = g.AddImport(guavaImmutableList) + ".copyOf(" + local + ")"
+			}
+			g.P(setter, "(", local, ");")
+		default:
+			value = javaFromMapValue(g, f.Value, value)
+			if g.isOptionalMessage(f) {
+				value = g.AddImport(guavaOptional) + ".of(" + value + ")"
+			}
+			g.P(setter, "(", value, ");")
+			if f.Oneof != nil {
+				g.P("bean.", javaSetterName(f.Oneof.Name+"Case"), "(", bean, ".", f.Oneof.CaseName(), ".", f.CaseConstant(), ");")
+			}
+		}
+		g.Out()
+		g.P("}")
+	}
+	g.P("return bean;")
+}
+
+// kotlinToMapValue returns the map value of the property
+func kotlinToMapValue(f *JavaField) string {
+	ref := "this." + f.Name
+	switch {
+	case f.Value.Kind == CustomKind:
+		return ref
+	case f.IsMap() && f.Value.Kind == MessageKind:
+		return ref + ".mapValues { it.value.toMap() }"
+	case f.IsMap() && f.Value.Kind == EnumKind:
+		return ref + ".mapValues { it.value.name }"
+	case f.IsMap():
+		return ref + ".toMap()"
+	case f.Repeated && f.Value.Kind == MessageKind:
+		return ref + ".map { it.toMap() }"
+	case f.Repeated && f.Value.Kind == EnumKind:
+		return ref + ".map { it.name }"
+	case f.Repeated:
+		// primitive arrays included
+		return ref + ".toList()"
+	case f.Value.Kind == MessageKind:
+		return ref + "?.toMap()"
+	case f.Value.Kind == EnumKind:
+		return ref + "?.name"
+	}
+	return ref
+}
+
+// kotlinFromMapValue returns a single value of the type read from the map value, which is not null
+func kotlinFromMapValue(g *Generator, t JavaType, value string) string {
+	switch t.Kind {
+	case MessageKind:
+		return g.beanRef(t.Object) + ".fromMap(" + value + " as Map<String, Any?>)"
+	case EnumKind:
+		return g.beanRef(t.Object) + ".valueOf(" + value + " as String)"
+	case AnyKind:
+		return value
+	}
+	switch t.Proto {
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return value + " as String"
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return value + " as ByteArray"
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return value + " as Boolean"
+	}
+	return "(" + value + " as Number).to" + strings.Title(numberType(t.Proto)) + "()"
+}
+
+// kotlinPopulateFromMap sets the properties of the bean from the values of the map which are not null,
+// the case of a oneof along with its member
+func kotlinPopulateFromMap(g *Generator, c *JavaClass) {
+	bean := g.beanRef(c.Desc)
+	g.P("val bean = ", c.Name, "()")
+	for _, f := range c.Fields {
+		get := `map["` + kotlinStringEscape(f.Proto.GetName()) + `"]?.let {`
+		typeName, _ := kotlinFieldType(g, f)
+		switch {
+		case f.Value.Kind == CustomKind:
+			g.P(get, " bean.", f.Name, " = it as ", typeName, " }")
+		case f.IsMap():
+			g.P(get, " bean.", f.Name, " = (it as Map<*, *>).entries.associate { e -> (",
+				kotlinFromMapValue(g, *f.Key, "e.key"), ") to (", kotlinFromMapValue(g, f.Value, "e.value"), ") } }")
+		case f.Repeated:
+			value := "(it as List<*>).map { v -> " + kotlinFromMapValue(g, f.Value, "v") + " }"
+			if conv, ok := kotlinArrayConversions[typeName]; ok {
+				value += "." + conv
+			}
+			g.P(get, " bean.", f.Name, " = ", value, " }")
+		case f.Oneof != nil:
+			g.P(get)
+			g.In()
+			g.P("bean.", f.Name, " = ", kotlinFromMapValue(g, f.Value, "it"))
+			g.P("bean.", f.Oneof.Name, "Case = ", bean, ".", f.Oneof.CaseName(), ".", f.CaseConstant())
+			g.Out()
+			g.P("}")
+		default:
+			g.P(get, " bean.", f.Name, " = ", kotlinFromMapValue(g, f.Value, "it"), " }")
+		}
+	}
+	g.P("return bean")
+}
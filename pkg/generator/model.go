@@ -0,0 +1,312 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// The model describes the beans and converters independently of the target language,
+// it is built from the descriptors once the options are read and rendered by the kotlin and java emitters,
+// which only decide how the model is spelled.
+
+// TypeKind classifies the values held by a bean property
+type TypeKind int
+
+const (
+	ScalarKind  TypeKind = iota // A scalar proto type, e.g. int32 or string
+	EnumKind                    // The bean of an enum
+	MessageKind                 // The bean of a message
+	AnyKind                     // A google.protobuf.Any, held as the bean of the packed message
+	CustomKind                  // A class set by the type option, left to hand-written conversions
+)
+
+// JavaType is the type of a single value of a bean property, or of the keys of a map
+type JavaType struct {
+	Kind   TypeKind
+	Proto  descriptor.FieldDescriptorProto_Type // Proto type of the values
+	Object Object                               // Message or enum of EnumKind and MessageKind values
+	Class  string                               // Fully-qualified class of CustomKind values
+}
+
+// JavaField is the bean property of a message field
+type JavaField struct {
+	Proto     *descriptor.FieldDescriptorProto
+	Name      string     // Name of the property
+	Label     string     // Label of the value in toString, the json name when set explicitly
+	Value     JavaType   // Type of the value, of the elements of lists or of the values of maps
+	Key       *JavaType  // Type of the keys of maps, nil for other fields
+	Repeated  bool       // Whether the property is a list or a map
+	Oneof     *JavaOneof // Oneof the field is a member of, including the synthetic oneofs of proto3 optional fields
+	Redacted  bool       // Whether toString hides the value
+	Converted bool       // Whether the converters copy the value
+	Path      string     // SourceCodeInfo path of the field, for comments
+}
+
+// IsMap reports whether the property is a map
+func (f *JavaField) IsMap() bool {
+	return f.Key != nil
+}
+
+// JavaOneof is a oneof of a message, its bean holds the case of the oneof
+type JavaOneof struct {
+	Name   string       // Camel cased name of the oneof
+	Index  int32        // Index of the oneof in the message
+	Fields []*JavaField // Members of the oneof which are not skipped
+}
+
+// CaseName returns the name of the case enum of the oneof, e.g. PaymentCase
+func (o *JavaOneof) CaseName() string {
+	return strings.Title(o.Name) + "Case"
+}
+
+// NotSetName returns the constant of the case enum set when no member of the oneof is, e.g. PAYMENT_NOT_SET
+func (o *JavaOneof) NotSetName() string {
+	return strings.ToUpper(o.Name) + "_NOT_SET"
+}
+
+// CaseConstant returns the constant of the case enum of its oneof set along with the field, e.g. CARD
+func (f *JavaField) CaseConstant() string {
+	return strings.ToUpper(f.Proto.GetName())
+}
+
+// oneofMember is the case the setter of a member of a oneof updates, a oneof holding one member at a time
+type oneofMember struct {
+	Case     string   // Property of the case of the oneof, e.g. paymentCase
+	CaseName string   // Case enum of the oneof, e.g. PaymentCase
+	Constant string   // Constant of the member, set when the member is
+	NotSet   string   // Constant set when the member is cleared while set
+	Others   []string // Properties of the other members, cleared when the member is set
+	Present  string   // Condition of the new value setting the member
+}
+
+// oneofMemberOf returns the case the setter of the field updates, nil when the field is not a member of a oneof
+func oneofMemberOf(f *JavaField, present string) *oneofMember {
+	o := f.Oneof
+	if o == nil {
+		return nil
+	}
+	m := &oneofMember{
+		Case:     o.Name + "Case",
+		CaseName: o.CaseName(),
+		Constant: f.CaseConstant(),
+		NotSet:   o.NotSetName(),
+		Present:  present,
+	}
+	for _, other := range o.Fields {
+		if other != f {
+			m.Others = append(m.Others, other.Name)
+		}
+	}
+	return m
+}
+
+// JavaClass is the bean of a message
+type JavaClass struct {
+	Desc       *Descriptor
+	Name       string       // Class name of the bean, without the enclosing classes
+	BaseClass  string       // Class extended by the bean, or empty
+	View       string       // Name of the read-only interface implemented by the bean with views=true, or empty
+	Fields     []*JavaField // Properties in declaration order or by number, see field_order, skipped fields excluded
+	Oneofs     []*JavaOneof // Oneofs in declaration order
+	Enums      []*JavaEnum  // Nested enums
+	Nested     []*JavaClass // Nested messages, map entries excluded
+	Recursive  bool         // Whether the bean can contain itself, toString then guards against cycles
+	Extendable bool         // Whether the bean holds the values of proto2 extensions
+	Path       string       // SourceCodeInfo path of the message, for comments
+}
+
+// JavaEnum is the bean of an enum
+type JavaEnum struct {
+	Desc    *EnumDescriptor
+	Name    string           // Class name of the bean, without the enclosing classes
+	Default int              // Index of the value set as the default constant, -1 when the emitter picks one
+	Values  []*JavaEnumValue // Constants in declaration order
+	Path    string           // SourceCodeInfo path of the enum, for comments
+}
+
+// JavaEnumValue is a constant of the bean of an enum
+type JavaEnumValue struct {
+	Name   string
+	Number int32
+	Path   string // SourceCodeInfo path of the value, for comments
+}
+
+// Converter is the class converting between the protobuf-java classes and the beans of a proto file
+type Converter struct {
+	File    *FileDescriptor
+	Name    string       // Class name of the converter
+	Package string       // Java package of the converter
+	Enums   []*JavaEnum  // Converted enums, nested ones included
+	Classes []*JavaClass // Converted messages, nested ones included
+}
+
+// buildType returns the type of a single value of the field
+func (g *Generator) buildType(field *descriptor.FieldDescriptorProto) JavaType {
+	t := JavaType{Kind: ScalarKind, Proto: field.GetType()}
+	switch {
+	case isAnyField(field):
+		t.Kind = AnyKind
+	case isMessage(field):
+		t.Kind = MessageKind
+		t.Object = g.ObjectNamed(field.GetTypeName())
+	case field.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM:
+		t.Kind = EnumKind
+		t.Object = g.ObjectNamed(field.GetTypeName())
+	}
+	return t
+}
+
+// buildField returns the bean property of the field of the message at the index
+func (g *Generator) buildField(msg *Descriptor, field *descriptor.FieldDescriptorProto, index int) *JavaField {
+	f := &JavaField{
+		Proto:     field,
+		Name:      g.fieldName(field),
+		Label:     explicitJSONName(field),
+		Value:     g.buildType(field),
+		Repeated:  isRepeated(field),
+		Redacted:  g.isFieldRedacted(field),
+		Converted: g.isFieldConverted(field),
+		Path:      fmt.Sprintf("%s,%d,%d", msg.path, messageFieldPath, index),
+	}
+	if f.Label == "" {
+		f.Label = f.Name
+	}
+	if class := g.fieldTypeOverride(field); class != "" {
+		f.Value = JavaType{Kind: CustomKind, Proto: field.GetType(), Class: class}
+		return f
+	}
+	if entry := g.mapEntryOf(field); entry != nil {
+		key := g.buildType(entry.Field[0])
+		f.Key = &key
+		f.Value = g.buildType(entry.Field[1])
+	}
+	return f
+}
+
+// The orders of the bean properties, chosen by the field_order parameter
+const (
+	fieldOrderDeclaration = "declaration" // As declared in the proto file, the default
+	fieldOrderNumber      = "number"      // By field number, stable when the fields are moved around in the proto file
+)
+
+// parseFieldOrder validates the field_order parameter
+func (g *Generator) parseFieldOrder(v string) string {
+	if v != fieldOrderDeclaration && v != fieldOrderNumber {
+		g.Fail("invalid field_order", v+", use declaration or number")
+	}
+	return v
+}
+
+// sortFieldsByNumber orders the properties by the numbers of their fields
+func sortFieldsByNumber(fields []*JavaField) {
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].Proto.GetNumber() < fields[j].Proto.GetNumber() })
+}
+
+// javaClass returns the bean of the message, the model is built once per message
+func (g *Generator) javaClass(msg *Descriptor) *JavaClass {
+	if c, ok := g.classes[msg]; ok {
+		return c
+	}
+	c := &JavaClass{
+		Desc:       msg,
+		Name:       g.beanName(msg),
+		BaseClass:  g.beanBaseClass(msg),
+		Recursive:  g.isRecursive(msg),
+		Extendable: isExtendable(msg),
+		Path:       msg.path,
+	}
+	if g.Views {
+		c.View = c.Name + "View"
+	}
+	oneofs := make(map[int32]*JavaOneof)
+	for i, field := range msg.Field {
+		if g.isFieldSkipped(field) {
+			continue
+		}
+		f := g.buildField(msg, field, i)
+		if field.OneofIndex != nil {
+			o, ok := oneofs[field.GetOneofIndex()]
+			if !ok {
+				o = &JavaOneof{
+					Name:  CamelCase(msg.OneofDecl[field.GetOneofIndex()].GetName()),
+					Index: field.GetOneofIndex(),
+				}
+				oneofs[o.Index] = o
+				c.Oneofs = append(c.Oneofs, o)
+			}
+			o.Fields = append(o.Fields, f)
+			f.Oneof = o
+		}
+		c.Fields = append(c.Fields, f)
+	}
+	sort.Slice(c.Oneofs, func(i, j int) bool { return c.Oneofs[i].Index < c.Oneofs[j].Index })
+	if g.FieldOrder == fieldOrderNumber {
+		sortFieldsByNumber(c.Fields)
+		for _, o := range c.Oneofs {
+			sortFieldsByNumber(o.Fields)
+		}
+	}
+	for _, e := range msg.enums {
+		c.Enums = append(c.Enums, g.javaEnum(e))
+	}
+	for _, d := range msg.nested {
+		if d.GetOptions().GetMapEntry() {
+			continue
+		}
+		c.Nested = append(c.Nested, g.javaClass(d))
+	}
+	if g.classes == nil {
+		g.classes = make(map[*Descriptor]*JavaClass)
+	}
+	g.classes[msg] = c
+	return c
+}
+
+// javaEnum returns the bean of the enum
+func (g *Generator) javaEnum(enum *EnumDescriptor) *JavaEnum {
+	e := &JavaEnum{
+		Desc:    enum,
+		Name:    g.beanName(enum),
+		Default: g.enumDefault(enum),
+		Path:    enum.path,
+	}
+	for i, v := range enum.Value {
+		e.Values = append(e.Values, &JavaEnumValue{
+			Name:   v.GetName(),
+			Number: v.GetNumber(),
+			Path:   fmt.Sprintf("%s,%d,%d", enum.path, enumValuePath, i),
+		})
+	}
+	return e
+}
+
+// buildConverter returns the converter of the file, warning when it cannot import the protobuf-java classes,
+// left in the unnamed package
+func (g *Generator) buildConverter(file *FileDescriptor) *Converter {
+	c := &Converter{
+		File:    file,
+		Name:    javaConverterName(file),
+		Package: g.converterPackage(file),
+	}
+	if protoJavaPackage(file) == "" {
+		g.Warn(warnPackage, "the protobuf-java classes are generated in the unnamed package, which the converter in",
+			c.Package, "cannot import, set java_package")
+	}
+	for _, e := range g.fileEnums(file) {
+		c.Enums = append(c.Enums, g.javaEnum(e))
+	}
+	for _, d := range g.fileDescriptors(file) {
+		if !d.GetOptions().GetMapEntry() {
+			c.Classes = append(c.Classes, g.javaClass(d))
+		}
+	}
+	return c
+}
+
+// Class returns the model of the bean of the message, for plugins rendering the bean in their own way
+func (g *Generator) Class(msg *Descriptor) *JavaClass {
+	return g.javaClass(msg)
+}
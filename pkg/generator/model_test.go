@@ -0,0 +1,196 @@
+package generator
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// modelGenerator returns a generator ready to build the model of the fixtures in testdata/fixtures.pb
+func modelGenerator(t *testing.T, parameter string) *Generator {
+	data, err := ioutil.ReadFile("testdata/fixtures.pb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := new(descriptor.FileDescriptorSet)
+	if err = proto.Unmarshal(data, set); err != nil {
+		t.Fatal(err)
+	}
+	g := New()
+	g.Request.ProtoFile = set.File
+	for _, f := range set.File {
+		if !strings.HasPrefix(f.GetName(), "google/protobuf/") {
+			g.Request.FileToGenerate = append(g.Request.FileToGenerate, f.GetName())
+		}
+	}
+	g.CommandLineParameters(parameter)
+	g.WrapTypes()
+	g.BuildTypeNameMap()
+	return g
+}
+
+// modelClass returns the model of the message with the proto full name,
+// the types are then spelled as in the source file of its bean
+func modelClass(t *testing.T, g *Generator, name string) *JavaClass {
+	msg, ok := g.typeNameToObject["."+name].(*Descriptor)
+	if !ok {
+		t.Fatalf("no message %s in the fixtures", name)
+	}
+	c := g.javaClass(msg)
+	g.beginImports(descriptorPackagePath(g, msg), c.Name)
+	g.reserveNested(c)
+	return c
+}
+
+// expandImports returns the text as printed with the classes referred to so far
+func expandImports(g *Generator, text string) string {
+	names, _ := g.imports.resolve(text)
+	return g.imports.expand(text, names)
+}
+
+func TestJavaClassFields(t *testing.T) {
+	g := modelGenerator(t, "")
+	c := modelClass(t, g, "shop.order.Order")
+	javaGen := modelGenerator(t, "lang=java")
+	javaClass := modelClass(t, javaGen, "shop.order.Order")
+
+	tests := []struct {
+		name     string
+		kind     TypeKind
+		key      bool
+		repeated bool
+		oneof    string
+		kotlin   string
+		java     string
+	}{
+		{"id", ScalarKind, false, false, "", "String", "String"},
+		{"state", EnumKind, false, false, "", "Order.State?", "Order.State"},
+		{"items", MessageKind, false, true, "", "List<Order.Item>", "List<Order.Item>"},
+		{"labels", ScalarKind, true, true, "", "Map<String, String>", "Map<String, String>"},
+		{"itemsByLine", MessageKind, true, true, "", "Map<Int, Order.Item>", "Map<Integer, Order.Item>"},
+		{"signature", ScalarKind, false, false, "", "ByteArray", "byte[]"},
+		{"note", ScalarKind, false, false, "note", "String?", "String"},
+		{"cardToken", ScalarKind, false, false, "payment", "String?", "String"},
+		{"voucherCode", ScalarKind, false, false, "payment", "String?", "String"},
+		{"total", MessageKind, false, false, "", "Money?", "Money"},
+		{"accepted", EnumKind, false, true, "", "List<Currency>", "List<Currency>"},
+	}
+	if len(c.Fields) != len(tests) {
+		t.Fatalf("got %d fields, want %d", len(c.Fields), len(tests))
+	}
+	for i, tt := range tests {
+		f := c.Fields[i]
+		if f.Name != tt.name {
+			t.Errorf("field %d is %s, want %s", i, f.Name, tt.name)
+			continue
+		}
+		if f.Value.Kind != tt.kind || f.IsMap() != tt.key || f.Repeated != tt.repeated {
+			t.Errorf("%s: got kind %d, map %v, repeated %v", f.Name, f.Value.Kind, f.IsMap(), f.Repeated)
+		}
+		oneof := ""
+		if f.Oneof != nil {
+			oneof = f.Oneof.Name
+		}
+		if oneof != tt.oneof {
+			t.Errorf("%s: got oneof %q, want %q", f.Name, oneof, tt.oneof)
+		}
+		kotlinType, _ := kotlinFieldType(g, f)
+		if typeName := expandImports(g, kotlinType); typeName != tt.kotlin {
+			t.Errorf("%s: got kotlin type %s, want %s", f.Name, typeName, tt.kotlin)
+		}
+		javaType, _ := javaFieldType(javaGen, javaClass.Fields[i])
+		if typeName := expandImports(javaGen, javaType); typeName != tt.java {
+			t.Errorf("%s: got java type %s, want %s", f.Name, typeName, tt.java)
+		}
+	}
+}
+
+func TestJavaClassFieldOrder(t *testing.T) {
+	tests := []struct {
+		parameter string
+		want      string
+		payment   string
+	}{
+		{"", "accepted total voucherCode cardToken note signature itemsByLine labels items state id", "voucherCode cardToken"},
+		{"field_order=declaration", "accepted total voucherCode cardToken note signature itemsByLine labels items state id", "voucherCode cardToken"},
+		{"field_order=number", "id state items labels itemsByLine signature note cardToken voucherCode total accepted", "cardToken voucherCode"},
+	}
+	for _, tt := range tests {
+		g := modelGenerator(t, tt.parameter)
+		// the fields declared in reverse, as if moved around in the proto file
+		msg := g.typeNameToObject[".shop.order.Order"].(*Descriptor)
+		for i, j := 0, len(msg.Field)-1; i < j; i, j = i+1, j-1 {
+			msg.Field[i], msg.Field[j] = msg.Field[j], msg.Field[i]
+		}
+		c := g.javaClass(msg)
+		names := make([]string, len(c.Fields))
+		for i, f := range c.Fields {
+			names[i] = f.Name
+		}
+		if got := strings.Join(names, " "); got != tt.want {
+			t.Errorf("%q: got %s, want %s", tt.parameter, got, tt.want)
+		}
+		payment := c.Oneofs[0].Fields
+		if got := payment[0].Name + " " + payment[1].Name; got != tt.payment {
+			t.Errorf("%q: got payment members %s, want %s", tt.parameter, got, tt.payment)
+		}
+	}
+}
+
+func TestJavaClassOneofs(t *testing.T) {
+	g := modelGenerator(t, "")
+	c := modelClass(t, g, "shop.order.Order")
+
+	// the synthetic oneof of the proto3 optional field is declared after payment
+	if len(c.Oneofs) != 2 || c.Oneofs[0].Name != "payment" || c.Oneofs[1].Name != "note" {
+		t.Fatalf("got oneofs %v", c.Oneofs)
+	}
+	payment := c.Oneofs[0]
+	if payment.CaseName() != "PaymentCase" || payment.NotSetName() != "PAYMENT_NOT_SET" {
+		t.Errorf("got case enum %s with %s", payment.CaseName(), payment.NotSetName())
+	}
+	if len(payment.Fields) != 2 || payment.Fields[0].CaseConstant() != "CARD_TOKEN" {
+		t.Errorf("got members %v", payment.Fields)
+	}
+}
+
+func TestJavaClassNaming(t *testing.T) {
+	g := modelGenerator(t, "bean_prefix=Pb,bean_suffix=VO")
+	c := modelClass(t, g, "shop.order.Order")
+
+	if c.Name != "PbOrderVO" {
+		t.Errorf("got class %s, want PbOrderVO", c.Name)
+	}
+	if len(c.Nested) != 1 || c.Nested[0].Name != "PbItemVO" {
+		t.Errorf("got nested classes %v, map entries must be left out", c.Nested)
+	}
+	if len(c.Enums) != 1 || c.Enums[0].Name != "PbStateVO" || c.Enums[0].Default != -1 {
+		t.Errorf("got nested enums %v", c.Enums)
+	}
+	itemsType, _ := kotlinFieldType(g, c.Fields[2])
+	if typeName := expandImports(g, itemsType); typeName != "List<PbOrderVO.PbItemVO>" {
+		t.Errorf("got kotlin type %s of items", typeName)
+	}
+}
+
+func TestSingular(t *testing.T) {
+	tests := map[string]string{
+		"items":     "item",
+		"entries":   "entry",
+		"addresses": "address",
+		"boxes":     "box",
+		"matches":   "match",
+		"status":    "status",
+		"accepted":  "accepted",
+		"bin":       "bin",
+		"s":         "s",
+	}
+	for name, want := range tests {
+		if got := singular(name); got != want {
+			t.Errorf("singular(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"regexp"
+	"sort"
+)
+
+// moduleInfoName is the name of the module declaration written with module=<name>, at the root of the output
+const moduleInfoName = "module-info.java"
+
+// The modules the generated code requires, by their module or Automatic-Module-Name
+const (
+	protobufModule = "com.google.protobuf" // protobuf-java, the converters and the type registry
+	guavaModule    = "com.google.common"   // Guava, the collections and Optional of guava=true
+	jspecifyModule = "org.jspecify"        // JSpecify, the annotations of nullability=jspecify
+	kotlinModule   = "kotlin.stdlib"       // The standard library of Kotlin
+)
+
+// moduleNamePattern matches the names of java modules, dotted java identifiers
+var moduleNamePattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*(\.[A-Za-z_$][A-Za-z0-9_$]*)*$`)
+
+// parseModule validates the module parameter
+func (g *Generator) parseModule(v string) string {
+	if !moduleNamePattern.MatchString(v) {
+		g.Fail("invalid module", v+", use module=<name> with a dotted module name, e.g. module=com.example.model")
+	}
+	return v
+}
+
+// addModulePackage records the java package of a generated file, exported by module-info.java
+func (g *Generator) addModulePackage(javaPackage string) {
+	if g.modulePackages == nil {
+		g.modulePackages = make(map[string]bool)
+	}
+	g.modulePackages[javaPackage] = true
+}
+
+// moduleRequires returns the requires directives of the modules the generated code depends on,
+// transitive when their types appear in the signatures of the beans or converters
+func (g *Generator) moduleRequires() []string {
+	var requires []string
+	if g.lang == LangKotlin {
+		requires = append(requires, "requires transitive "+kotlinModule+";")
+	}
+	if !g.NoConverters {
+		requires = append(requires, "requires transitive "+protobufModule+";")
+	}
+	if g.Guava {
+		requires = append(requires, "requires transitive "+guavaModule+";")
+	}
+	if g.Nullability == nullabilityJSpecify {
+		// the annotations are not needed at run time
+		requires = append(requires, "requires static "+jspecifyModule+";")
+	}
+	return requires
+}
+
+// generateModuleInfo writes the module declaration exporting every package of the generated classes
+func (g *Generator) generateModuleInfo() {
+	if g.modulePackages[""] {
+		g.Fail("module="+g.Module, "cannot export the classes generated in the unnamed package, set vopkg or java_package")
+	}
+	packages := make([]string, 0, len(g.modulePackages))
+	for pkg := range g.modulePackages {
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+
+	g.Reset()
+	if g.HeaderTemplate != "" {
+		populateCustomHeader(g, g.genFiles...)
+	} else {
+		populateHeaderComment(g, g.genFiles...)
+	}
+	g.P("module ", g.Module, " {")
+	g.In()
+	for _, r := range g.moduleRequires() {
+		g.P(r)
+	}
+	g.P()
+	for _, pkg := range packages {
+		g.P("exports ", pkg, ";")
+	}
+	g.Out()
+	g.P("}")
+	g.addOutputFile(moduleInfoName, g.genFiles, nil)
+}
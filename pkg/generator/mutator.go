@@ -0,0 +1,69 @@
+package generator
+
+import "strings"
+
+// collectionMutator is the add and put methods of a list or map property rendered by the mutators template,
+// sparing the callers the checks before modifying the collection
+type collectionMutator struct {
+	Name   string // Name of the property
+	Add    string // Method adding an element, e.g. addItem, or putting an entry, e.g. putLabel
+	AddAll string // Method adding the elements of a collection, e.g. addAllItems, empty for maps
+	Key    string // Type of the keys of maps, empty for lists
+	Value  string // Type of the elements of lists or of the values of maps
+	Init   string // Initial value of the property, set before adding to a missing collection
+	Guava  bool   // Whether the collection is immutable with guava=true, the mutators replace it by a copy
+}
+
+// collectionMutators returns the mutators of the list and map properties of the bean,
+// valueType spells the type of the values and fieldType the type and initial value of the properties
+func collectionMutators(c *JavaClass, valueType func(JavaType) string, fieldType func(*JavaField) (string, string)) []collectionMutator {
+	var mutators []collectionMutator
+	for _, f := range c.Fields {
+		if !f.Repeated || f.Value.Kind == CustomKind {
+			continue
+		}
+		_, init := fieldType(f)
+		m := collectionMutator{Name: f.Name, Value: valueType(f.Value), Init: init}
+		m.Add, m.AddAll = mutatorNames(c, f)
+		if f.IsMap() {
+			m.Key = valueType(*f.Key)
+		}
+		mutators = append(mutators, m)
+	}
+	return mutators
+}
+
+// mutatorNames returns the names of the methods adding an element to the list or putting an entry into the map
+// and adding the elements of a collection, empty for maps. They are named after the singular of the property,
+// unless another property is named so.
+func mutatorNames(c *JavaClass, f *JavaField) (add, addAll string) {
+	element := singular(f.Name)
+	for _, other := range c.Fields {
+		if other.Name == element {
+			// e.g. item and items, the methods of items keep the plural
+			element = f.Name
+			break
+		}
+	}
+	if f.IsMap() {
+		return "put" + strings.Title(element), ""
+	}
+	return "add" + strings.Title(element), "addAll" + strings.Title(f.Name)
+}
+
+// singular returns the singular of the name of a list or map property, e.g. item of items and entry of entries,
+// names which do not look plural are returned as they are
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"),
+		strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "ss"), strings.HasSuffix(name, "us"), strings.HasSuffix(name, "is"):
+		return name
+	case strings.HasSuffix(name, "s") && len(name) > 1:
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
@@ -0,0 +1,75 @@
+package generator
+
+import "strings"
+
+// The nullness annotations of the java beans, chosen by the nullability parameter
+const (
+	nullabilityNone     = "none"     // No annotations, the default
+	nullabilityJSpecify = "jspecify" // @NullMarked packages and @Nullable properties of JSpecify
+)
+
+// The JSpecify annotations
+const (
+	jspecifyNullMarked = "org.jspecify.annotations.NullMarked"
+	jspecifyNullable   = "org.jspecify.annotations.Nullable"
+)
+
+// parseNullability validates the nullability parameter
+func (g *Generator) parseNullability(v string) string {
+	if v != nullabilityNone && v != nullabilityJSpecify {
+		g.Fail("invalid nullability", v+", use none or jspecify")
+	}
+	return v
+}
+
+// isNullable reports whether the property is annotated as nullable, the properties initially null,
+// i.e. messages, enums, members of oneofs and proto3 optional fields
+func (g *Generator) isNullable(f *JavaField) bool {
+	if g.Nullability != nullabilityJSpecify {
+		return false
+	}
+	_, value := javaFieldType(g, f)
+	return value == "null"
+}
+
+// nullableType returns the java type annotated with @Nullable. As a type-use annotation it goes right before
+// the simple name of a qualified type, e.g. User.@Nullable Address, and before the brackets of an array.
+func (g *Generator) nullableType(typeName string) string {
+	annotation := g.AddImport(jspecifyNullable)
+	raw, args := typeName, ""
+	if i := strings.IndexByte(typeName, '<'); i >= 0 {
+		raw, args = typeName[:i], typeName[i:]
+	}
+	if strings.HasSuffix(raw, "[]") {
+		return strings.TrimSuffix(raw, "[]") + " @" + annotation + " []" + args
+	}
+	dot := strings.LastIndexByte(raw, '.')
+	if end := strings.LastIndexByte(raw, '\x00'); end > dot {
+		// a class referred to by a placeholder, expanded to a simple or a qualified name
+		start := strings.LastIndexByte(raw[:end], '\x00')
+		return raw[:start] + annotatedPlaceholder(raw[start:end+1], annotation) + raw[end+1:] + args
+	}
+	return raw[:dot+1] + "@" + annotation + " " + raw[dot+1:] + args
+}
+
+// generatePackageInfo writes the package-info.java marking the package of the beans as @NullMarked,
+// once per package and output directory
+func (g *Generator) generatePackageInfo(file *FileDescriptor, javaPackage string) {
+	if g.Nullability != nullabilityJSpecify || javaPackage == "" {
+		return
+	}
+	name := g.outputFileName(file, javaPackage, "package-info")
+	if pkg, ok := g.packageInfos[name]; ok {
+		if pkg != javaPackage {
+			g.Warn(warnParameter, "no package-info.java generated for", javaPackage+",", name, "is generated for", pkg)
+		}
+		return
+	}
+	if g.packageInfos == nil {
+		g.packageInfos = make(map[string]string)
+	}
+	g.packageInfos[name] = javaPackage
+	g.Reset()
+	populatePreamble(g, "@"+jspecifyNullMarked+"\npackage "+javaPackage+";", file)
+	g.addOutputFile(name, []*FileDescriptor{file}, nil)
+}
@@ -0,0 +1,311 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// beanOptionNumber is the field number of the extensions declared by bean/options.proto,
+// the plugin does not link the extensions so protoc passes them as unknown fields of the options.
+const beanOptionNumber = 51720
+
+// Field numbers of the field rules of protoc-gen-validate, (validate.rules).message.required
+const (
+	validateRulesNumber   = 1071
+	validateMessageRules  = 17
+	validateRulesRequired = 2
+)
+
+// Field numbers of the option messages of bean/options.proto
+const (
+	optionSkip      = 1 // all
+	optionName      = 2 // message, enum and field
+	optionPackage   = 2 // file
+	optionBaseClass = 3 // message
+	optionRedact    = 3 // field
+	optionDefault   = 3 // enum
+	optionType      = 4 // field
+)
+
+// directivePrefix starts the comment lines read as bean options, e.g. // bean:name=OrderVO
+const directivePrefix = "bean:"
+
+// Directive names by the field numbers of the option messages
+var (
+	messageDirectives = map[string]protowire.Number{"skip": optionSkip, "name": optionName, "base_class": optionBaseClass}
+	enumDirectives    = map[string]protowire.Number{"skip": optionSkip, "name": optionName, "default": optionDefault}
+	fieldDirectives   = map[string]protowire.Number{"skip": optionSkip, "name": optionName, "redact": optionRedact, "type": optionType}
+)
+
+// fieldOptions holds the bean options of a single message field
+type fieldOptions struct {
+	Skip   bool   // leave the field out of the bean and the converter
+	Name   string // bean property name replacing the camel cased field name
+	Redact bool   // hide the value in toString
+	Type   string // type of the bean property, not converted
+}
+
+// optionValues holds the fields of a bean option message by number, varints as uint64 and bytes as string
+type optionValues map[protowire.Number]interface{}
+
+func (o optionValues) bool(n protowire.Number) bool {
+	switch v := o[n].(type) {
+	case uint64:
+		return v != 0
+	case string:
+		// set by a directive
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return false
+}
+
+func (o optionValues) string(n protowire.Number) string {
+	v, _ := o[n].(string)
+	return v
+}
+
+// readBeanOption decodes the bean option from the unknown fields of the descriptor options,
+// nil is returned if the option is not set
+func readBeanOption(opts protoreflect.ProtoMessage) optionValues {
+	return readOptionMessage(opts, beanOptionNumber)
+}
+
+// readOptionMessage decodes the scalar fields of the message option of the number from the unknown fields
+// of the descriptor options, nil is returned if the option is not set
+func readOptionMessage(opts protoreflect.ProtoMessage, number protowire.Number) optionValues {
+	if opts == nil || !opts.ProtoReflect().IsValid() {
+		return nil
+	}
+	var values optionValues
+	b := opts.ProtoReflect().GetUnknown()
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return values
+		}
+		b = b[n:]
+		if num == number && typ == protowire.BytesType {
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return values
+			}
+			// occurrences of a message option are merged
+			if values == nil {
+				values = make(optionValues)
+			}
+			decodeOptionValues(v, values)
+		}
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return values
+		}
+		b = b[n:]
+	}
+	return values
+}
+
+// decodeOptionValues decodes the scalar fields of an option message
+func decodeOptionValues(b []byte, values optionValues) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return
+		}
+		b = b[n:]
+		switch typ {
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return
+			}
+			values[num] = v
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return
+			}
+			values[num] = string(v)
+		}
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return
+		}
+		b = b[n:]
+	}
+}
+
+// isDirective reports whether the comment line is a bean directive
+func isDirective(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), directivePrefix)
+}
+
+// readDirectives decodes the directives in the leading comment of the element at the path,
+// e.g. "bean:skip" or "bean:name=OrderVO", and adds those not set by an option to the values.
+func (g *Generator) readDirectives(file *FileDescriptor, path, element string, names map[string]protowire.Number, values optionValues) optionValues {
+	loc, ok := file.locations[path]
+	if !ok {
+		return values
+	}
+	for _, line := range strings.Split(loc.GetLeadingComments(), "\n") {
+		if !isDirective(line) {
+			continue
+		}
+		directive := strings.TrimPrefix(strings.TrimSpace(line), directivePrefix)
+		name, value := directive, "true"
+		if i := strings.Index(directive, "="); i >= 0 {
+			name, value = strings.TrimSpace(directive[:i]), strings.TrimSpace(directive[i+1:])
+		}
+		num, ok := names[name]
+		if !ok {
+			g.Warn(warnDirective, fmt.Sprintf("%s: unknown directive %q of %s", file.GetName(), name, element))
+			continue
+		}
+		if values == nil {
+			values = make(optionValues)
+		}
+		if _, ok := values[num]; !ok {
+			values[num] = value
+		}
+	}
+	return values
+}
+
+// fileBeanPackage returns the bean package set by the file option, or an empty string
+func fileBeanPackage(f *descriptor.FileDescriptorProto) string {
+	return readBeanOption(f.GetOptions()).string(optionPackage)
+}
+
+// readOptions applies the options of bean/options.proto and the comment directives declared in the generated files,
+// settings of the config file take precedence over the options, which take precedence over the directives.
+func (g *Generator) readOptions() {
+	g.fieldOptions = make(map[*descriptor.FieldDescriptorProto]fieldOptions)
+	if g.MessageOverrides == nil {
+		g.MessageOverrides = make(map[string]MessageOverride)
+	}
+
+	override := func(obj Object, o MessageOverride) {
+		name := protoFullName(obj)
+		if _, ok := g.MessageOverrides[name]; ok {
+			return
+		}
+		g.MessageOverrides[name] = o
+		if o.Skip {
+			g.Exclude = append(g.Exclude, name)
+		}
+	}
+
+	for _, file := range g.genFiles {
+		if readBeanOption(file.GetOptions()).bool(optionSkip) {
+			g.Exclude = append(g.Exclude, file.GetName())
+		}
+		for _, e := range file.enum {
+			if g.SkipDeprecated && e.parent == nil && e.GetOptions().GetDeprecated() {
+				g.Exclude = append(g.Exclude, protoFullName(e))
+			}
+			o := readBeanOption(e.GetOptions())
+			o = g.readDirectives(file, e.path, protoFullName(e), enumDirectives, o)
+			if o != nil {
+				override(e, MessageOverride{
+					Name:    o.string(optionName),
+					Skip:    o.bool(optionSkip),
+					Default: o.string(optionDefault),
+				})
+			}
+		}
+		for _, d := range file.desc {
+			if g.SkipDeprecated && d.parent == nil && d.GetOptions().GetDeprecated() {
+				g.Exclude = append(g.Exclude, protoFullName(d))
+			}
+			o := readBeanOption(d.GetOptions())
+			o = g.readDirectives(file, d.path, protoFullName(d), messageDirectives, o)
+			if o != nil {
+				override(d, MessageOverride{
+					Name:      o.string(optionName),
+					Skip:      o.bool(optionSkip),
+					BaseClass: o.string(optionBaseClass),
+				})
+			}
+			for i, field := range d.Field {
+				o := readBeanOption(field.GetOptions())
+				path := fmt.Sprintf("%s,%d,%d", d.path, messageFieldPath, i)
+				o = g.readDirectives(file, path, protoFullName(d)+"."+field.GetName(), fieldDirectives, o)
+				var fo fieldOptions
+				if o != nil {
+					fo = fieldOptions{
+						Skip:   o.bool(optionSkip),
+						Name:   o.string(optionName),
+						Redact: o.bool(optionRedact),
+						Type:   o.string(optionType),
+					}
+				}
+				if class := g.configType(d, field); class != "" {
+					fo.Type = class
+				}
+				if isRequired(field) && (fo.Skip || fo.Type != "") {
+					g.FailAt(file, path, protoFullName(d)+"."+field.GetName(), "required field must be converted, it cannot be skipped or retyped")
+				}
+				g.fieldOptions[field] = fo
+				if g.SkipDeprecated && field.GetOptions().GetDeprecated() {
+					if isRequired(field) {
+						g.Warn(warnDeprecated, "kept required field", protoFullName(d)+"."+field.GetName())
+						continue
+					}
+					fo := g.fieldOptions[field]
+					fo.Skip = true
+					g.fieldOptions[field] = fo
+				}
+			}
+		}
+	}
+}
+
+// isFieldSkipped reports whether the field is left out of the bean and its converter
+func (g *Generator) isFieldSkipped(field *descriptor.FieldDescriptorProto) bool {
+	return g.fieldOptions[field].Skip
+}
+
+// fieldName returns the name of the bean property of the field
+func (g *Generator) fieldName(field *descriptor.FieldDescriptorProto) string {
+	if name := g.fieldOptions[field].Name; name != "" {
+		return name
+	}
+	return javaFieldName(field)
+}
+
+// fieldTypeOverride returns the bean property type set by the type option of the field,
+// or an empty string. The converters leave such properties to hand-written code.
+func (g *Generator) fieldTypeOverride(field *descriptor.FieldDescriptorProto) string {
+	return g.fieldOptions[field].Type
+}
+
+// isFieldConverted reports whether the converters copy the field between the protobuf message and the bean
+func (g *Generator) isFieldConverted(field *descriptor.FieldDescriptorProto) bool {
+	return !g.isFieldSkipped(field) && g.fieldTypeOverride(field) == ""
+}
+
+// isFieldRedacted reports whether the value of the field is hidden in toString
+func (g *Generator) isFieldRedacted(field *descriptor.FieldDescriptorProto) bool {
+	return g.fieldOptions[field].Redact
+}
+
+// isFieldRequired reports whether the field must be set, a proto2 required field
+// or a message field required by protoc-gen-validate
+func isFieldRequired(field *descriptor.FieldDescriptorProto) bool {
+	if isRequired(field) {
+		return true
+	}
+	rules := readOptionMessage(field.GetOptions(), validateRulesNumber)
+	message, ok := rules[validateMessageRules].(string)
+	if !ok {
+		return false
+	}
+	values := make(optionValues)
+	decodeOptionValues([]byte(message), values)
+	return values.bool(validateRulesRequired)
+}
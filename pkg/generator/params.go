@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// parameter describes a command line parameter of the plugin
+type parameter struct {
+	name  string
+	usage string
+}
+
+// parameters lists every parameter understood by CommandLineParameters
+var parameters = []parameter{
+	{"vopkg=<package>", "java package of the beans, derived from java_package when omitted"},
+	{"require_vopkg=true|false", "fail instead of deriving the package when vopkg is omitted"},
+	{"lang=kotlin|java", "target language, default is kotlin"},
+	{"timestamp=true|false", "generate timestamp to file header, default is false"},
+	{"line_ending=lf|crlf", "line terminator of the generated files, default is lf"},
+	{"line_width=<n>", "width the statements of toString are wrapped at, default is 100"},
+	{"to_string=builder|joiner|guava|none", "style of the generated toString, default is builder"},
+	{"field_order=declaration|number", "order of the bean properties, default is declaration"},
+	{"clear=true|false", "generate a clear method in every bean resetting its properties to the values of a new bean, e.g. for pooled beans"},
+	{"mutators=true|false", "generate the add and put mutators of the list and map properties, e.g. addItem and putLabel"},
+	{"factories=true|false", "generate the static factories of, taking every property, and from, converting the protobuf message"},
+	{"defensive_copies=true|false", "copy the lists, maps and byte arrays set on and read from the beans, default is false"},
+	{"guava=true|false", "java only, use ImmutableList, ImmutableMap, Optional and MoreObjects.toStringHelper of Guava, default is false"},
+	{"nullability=none|jspecify", "java only, annotate the beans with the nullness annotations of JSpecify, default is none"},
+	{"scalars=primitive|boxed", "types of the singular numbers and booleans, boxed ones are null until set, default is primitive"},
+	{"views=true|false", "generate a read-only <Bean>View interface of the getters of every bean, implemented by the bean"},
+	{"fields_enum=true|false", "generate a Fields enum of the fields of every message and get and set accessors taking its constants"},
+	{"visitor=true|false", "generate the FieldVisitor interface and a visit method in every bean calling it back with every property"},
+	{"equals=true|false", "generate equals and hashCode in every bean, comparing the values of the properties"},
+	{"diff=true|false", "generate a static diff method in every bean returning the paths of the fields differing between two beans"},
+	{"to_map=true|false", "generate toMap and fromMap methods in every bean holding the properties in maps keyed by field names"},
+	{"base64=true|false", "generate the conversions of the converters from and to Base64 strings of the serialized messages"},
+	{"text_format=true|false", "generate the conversions of the converters from and to the protobuf text format"},
+	{"json_schema=true|false", "generate a <Bean>.schema.json JSON Schema of every top-level message next to its bean"},
+	{"source_locations=true|false", "follow the comments of the generated classes and fields with the proto file and line they are declared at"},
+	{"descriptors=true|false", "embed the descriptors of the proto files in a ProtoDescriptors class with a descriptor() accessor in every bean package"},
+	{"module=<name>", "generate a module-info.java declaring the module, exporting the packages of the beans and converters"},
+	{"javaver=7|8|11|17", "java only, language level of the generated code, default is 8"},
+	{"constructors=all;required", "java only, generate the all-args and the required fields constructors besides a no-arg one"},
+	{"wildcard_imports=<n>", "import a package with a wildcard once n of its classes are imported, default is 0, off"},
+	{"M<file>=<package>", "java package of the beans of a proto file"},
+	{"pkgmap=<proto.package>:<package>;...", "java packages of the beans of proto packages"},
+	{"paths=import|source_relative", "place output files by java package or next to the proto files"},
+	{"bean_prefix=<prefix>", "prepended to the names of the generated classes"},
+	{"bean_suffix=<suffix>", "appended to the names of the generated classes"},
+	{"bundle=true|false", "kotlin only, write the beans of a proto file into a single <File>Beans.kt"},
+	{"beans=true|false", "generate the beans, default is true"},
+	{"converters=true|false", "generate the converters and the type registry, default is true"},
+	{"skip_deprecated=true|false", "leave deprecated fields, messages and enums out of the beans and converters"},
+	{"include=<glob>;...", "generate only the proto files and messages matching the patterns"},
+	{"exclude=<glob>;...", "skip the proto files and messages matching the patterns"},
+	{"archive=srcjar", "package the generated files into beans.srcjar"},
+	{"manifest=true|false", "generate bean-manifest.json listing the generated files"},
+	{"config=<file>", "load the parameters from a yaml or json file"},
+	{"plugins=<name>;...", "run the registered plugins extending the beans, for binaries built with plugins"},
+	{"header=<file>", "custom header template replacing the built-in header comment"},
+	{"verbose=true|false", "log the parameters and every generated file"},
+	{"quiet=true|false", "log errors only"},
+	{"strict=true|false", "fail on warnings such as unknown parameters or heuristic enum defaults"},
+	{"debug_dump=<file>", "save the request from protoc, replay it with protoc-gen-bean < file"},
+	{"flavor=kotlin|java", "deprecated alias of lang"},
+	{"notime=true|false", "deprecated inverse of timestamp"},
+}
+
+// parameterName returns the key of the parameter, e.g. vopkg for vopkg=<package>
+func parameterName(p parameter) string {
+	if i := strings.Index(p.name, "="); i >= 0 {
+		return p.name[:i]
+	}
+	return p.name
+}
+
+// isKnownParameter reports whether the key is one of the supported parameters
+func isKnownParameter(k string) bool {
+	if k == "" {
+		return true
+	}
+	for _, p := range parameters {
+		if parameterName(p) == k {
+			return true
+		}
+	}
+	return false
+}
+
+// boolParam parses the value of the boolean parameter, a bare key being true,
+// values other than true and false fail the generation
+func (g *Generator) boolParam(k, v string) bool {
+	switch {
+	case v == "" || strings.EqualFold(v, "true"):
+		return true
+	case !strings.EqualFold(v, "false"):
+		g.Fail("invalid value", v, "of parameter", k+", use true or false")
+	}
+	return false
+}
+
+// suggestParameter returns the supported parameter closest to the unknown key,
+// or an empty string if none of them is close enough to be a typo.
+func suggestParameter(k string) string {
+	best, bestDistance := "", len(k)/2+1
+	for _, p := range parameters {
+		name := parameterName(p)
+		if strings.HasPrefix(name, "M<") {
+			continue
+		}
+		if d := editDistance(strings.ToLower(k), name); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// PrintParameters writes a summary of the supported parameters
+func PrintParameters(w io.Writer) {
+	width := 0
+	for _, p := range parameters {
+		if len(p.name) > width {
+			width = len(p.name)
+		}
+	}
+	for _, p := range parameters {
+		_, _ = fmt.Fprintf(w, "  %-*s  %s\n", width, p.name, p.usage)
+	}
+}
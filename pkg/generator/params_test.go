@@ -0,0 +1,58 @@
+package generator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/master-g/protoc-gen-bean/pkg/generator"
+)
+
+// TestBoolParameters checks a bare boolean parameter is true and other values than true and false fail
+func TestBoolParameters(t *testing.T) {
+	resp, err := generator.Run(fixturesRequest(t, "manifest"), generator.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := false
+	for _, f := range resp.File {
+		manifest = manifest || f.GetName() == "bean-manifest.json"
+	}
+	if !manifest {
+		t.Error("a bare manifest generated no bean-manifest.json")
+	}
+
+	for _, parameter := range []string{"manifest=yes", "beans=yes", "converters=on"} {
+		_, err = generator.Run(fixturesRequest(t, parameter), generator.Options{})
+		k, v := parameter[:strings.IndexByte(parameter, '=')], parameter[strings.IndexByte(parameter, '=')+1:]
+		if err == nil || !strings.Contains(err.Error(), "invalid value "+v+" of parameter "+k+", use true or false") {
+			t.Errorf("%s got error %v, want a failure of the invalid value", parameter, err)
+		}
+	}
+}
+
+// TestInvalidParameters checks the invalid values and combinations of the parameters fail with their message
+func TestInvalidParameters(t *testing.T) {
+	tests := []struct {
+		parameter string
+		message   string
+	}{
+		{"constructors=all", "constructors is only supported by lang=java"},
+		{"guava=true", "guava=true is only supported by lang=java"},
+		{"nullability=jspecify", "nullability=jspecify is only supported by lang=java"},
+		{"lang=java,nullability=checker", "invalid nullability checker"},
+		{"javaver=8", "javaver is only supported by lang=java"},
+		{"lang=java,javaver=9", "invalid javaver 9"},
+		{"lang=java,javaver=7,to_string=joiner", "to_string=joiner requires javaver=8 or above"},
+		{"lang=java,javaver=7,base64=true", "base64=true requires javaver=8 or above"},
+		{"lang=java,javaver=7,descriptors=true", "descriptors=true requires javaver=8 or above"},
+		{"scalars=wrapped", "invalid scalars wrapped"},
+		{"lang=java,module=com.example.shop", "module requires javaver=11 or above"},
+		{"module=com.example..shop", "invalid module com.example..shop"},
+	}
+	for _, tt := range tests {
+		_, err := generator.Run(fixturesRequest(t, tt.parameter), generator.Options{})
+		if err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("%s: got error %v, want %s", tt.parameter, err, tt.message)
+		}
+	}
+}
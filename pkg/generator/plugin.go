@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// Plugin extends the generated beans with custom code, e.g. extra methods, or adds companion files.
+// Third parties build their own binary registering plugins with RegisterPlugin, or pass them to Run.
+type Plugin interface {
+	// Name identifies the plugin in the plugins parameter
+	Name() string
+	// Init is called once before the generation, after the parameters are parsed and the types are resolved
+	Init(g *Generator)
+	// GenerateImports returns the fully-qualified names imported by the code Generate adds to the bean of the message,
+	// a class whose simple name refers to another class in the file is not imported, see AddImport
+	GenerateImports(msg *Descriptor) []string
+	// Generate prints members into the body of the bean class of the message with g.P,
+	// companion files may be added with g.AddFile
+	Generate(msg *Descriptor)
+}
+
+// plugins are the registered plugins, in registration order
+var plugins []Plugin
+
+// RegisterPlugin installs a plugin, typically from an init function,
+// registered plugins run when listed in the plugins parameter.
+func RegisterPlugin(p Plugin) {
+	plugins = append(plugins, p)
+}
+
+// enablePlugins selects the registered plugins listed in the plugins parameter
+func (g *Generator) enablePlugins(names string) {
+	for _, name := range strings.Split(names, ";") {
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, p := range plugins {
+			if p.Name() == name {
+				g.plugins = append(g.plugins, p)
+				found = true
+				break
+			}
+		}
+		if !found {
+			g.Fail("unknown plugin", name)
+		}
+	}
+}
+
+// initPlugins initializes the enabled plugins
+func (g *Generator) initPlugins() {
+	for _, p := range g.plugins {
+		g.Debugf("plugin %s enabled", p.Name())
+		p.Init(g)
+	}
+}
+
+// populatePlugins generates the members added by the plugins to the bean of the message
+func (g *Generator) populatePlugins(msg *Descriptor) {
+	for _, p := range g.plugins {
+		for _, imp := range p.GenerateImports(msg) {
+			g.pinImport(imp)
+		}
+		start := g.Len()
+		p.Generate(msg)
+		if g.Len() > start {
+			g.Newline()
+		}
+	}
+}
+
+// AddFile adds a file generated by a plugin to the response, e.g. a companion of the beans of the current proto file
+func (g *Generator) AddFile(name, content string) {
+	sources := make([]*FileDescriptor, 0, 1)
+	if g.file != nil {
+		sources = append(sources, g.file)
+	}
+	g.addFile(name, content, sources, nil)
+}
+
+// Lang returns the target language, LangKotlin or LangJava
+func (g *Generator) Lang() int {
+	return g.lang
+}
+
+// BeanName returns the class name of the bean of the message or enum, without the enclosing classes
+func (g *Generator) BeanName(obj Object) string {
+	return g.beanName(obj)
+}
+
+// PropertyName returns the name of the bean property of the field
+func (g *Generator) PropertyName(field *descriptor.FieldDescriptorProto) string {
+	return g.fieldName(field)
+}
@@ -0,0 +1,193 @@
+package generator
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// The beans, converters and type registries are rendered by text/template templates, a directory per language.
+// Templates receive the model, see model.go, and print their lines without indentation, nested declarations
+// are indented with the indent function. The functions below are shared by both languages,
+// javaTemplateFuncs and kotlinTemplateFuncs spell the types and names of each.
+
+// templateFS holds the templates of both languages
+//
+//go:embed templates
+var templateFS embed.FS
+
+// numberTable is how forNumber of a large enum looks its values up, see enumNumberTable
+type numberTable struct {
+	Dense  bool // Whether the table is an array indexed by number rather than a map
+	Length int  // Length of the array
+}
+
+// classConverter is the conversion of a message rendered by the messageConverter templates
+type classConverter struct {
+	File  *FileDescriptor // File converted by the converter holding the conversion
+	Class *JavaClass
+}
+
+// parseTemplates returns the templates of the target language, parsed on first use
+func (g *Generator) parseTemplates() *template.Template {
+	if g.templates != nil {
+		return g.templates
+	}
+	funcs := g.templateFuncs()
+	lang, langFuncs := "kotlin", kotlinTemplateFuncs(g)
+	if g.lang == LangJava {
+		lang, langFuncs = "java", javaTemplateFuncs(g)
+	}
+	for name, fn := range langFuncs {
+		funcs[name] = fn
+	}
+	t, err := template.New(lang).Funcs(funcs).ParseFS(templateFS, "templates/"+lang+"/*.tmpl")
+	if err != nil {
+		g.Error(err, "parsing the", lang, "templates")
+	}
+	g.templates = t
+	return t
+}
+
+// templateFuncs returns the functions available to the templates of both languages
+func (g *Generator) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"camelCase": CamelCase,
+		"import":    g.AddImport,
+		"bean":      g.beanRef,
+		"pbClass":   g.pbClassName,
+		"fullName":  protoFullName,
+		"typeName": func(obj Object) string {
+			return strings.Join(obj.TypeName(), "")
+		},
+		"nested": func(obj Object) bool {
+			return len(obj.TypeName()) > 1
+		},
+		"deprecated": func(obj Object) bool {
+			switch o := obj.(type) {
+			case *Descriptor:
+				return o.GetOptions().GetDeprecated()
+			case *EnumDescriptor:
+				return o.GetOptions().GetDeprecated()
+			}
+			return false
+		},
+		"comments": func(path string) string {
+			c, _ := g.makeComments(path)
+			return c
+		},
+		"tail": func(path string) string {
+			if c, ok := g.tailingComments(path); ok {
+				return " " + c
+			}
+			return ""
+		},
+		"reserved": func(obj Object) string {
+			switch o := obj.(type) {
+			case *Descriptor:
+				return messageReserved(o)
+			case *EnumDescriptor:
+				return enumReserved(o)
+			}
+			return ""
+		},
+		"insertionPoint": insertionPoint,
+		"plugins": func(msg *Descriptor) string {
+			return g.capture(func() { g.populatePlugins(msg) })
+		},
+		"numberTable": func(e *JavaEnum) *numberTable {
+			if table, dense, length := enumNumberTable(e.Desc); table {
+				return &numberTable{Dense: dense, Length: length}
+			}
+			return nil
+		},
+		"proto3": func(obj Object) bool {
+			return fileIsProto3(obj.File().FileDescriptorProto)
+		},
+		"unusedNumber": unusedEnumNumber,
+		"converterOf": func(file *FileDescriptor, c *JavaClass) classConverter {
+			return classConverter{File: file, Class: c}
+		},
+		"converterRef": func(d *Descriptor) string {
+			return g.converterClassRef(g.registryPackage(), d)
+		},
+		"toStringStyle": func() string {
+			return g.ToString
+		},
+		"views": func() bool {
+			return g.Views
+		},
+		"clear": func() bool {
+			return g.Clear
+		},
+		"toMap": func() bool {
+			return g.ToMap
+		},
+		"base64": func() bool {
+			return g.Base64
+		},
+		"textFormat": func() bool {
+			return g.TextFormat
+		},
+		"interfaces": g.beanInterfaces,
+		"quote":      strconv.Quote,
+		"include":    g.execute,
+		"indent":     indentLines,
+		"isLast": func(i int, list interface{}) bool {
+			return i == reflect.ValueOf(list).Len()-1
+		},
+	}
+}
+
+// execute returns the output of the template, without leading and trailing empty lines
+func (g *Generator) execute(name string, data interface{}) string {
+	var b strings.Builder
+	if err := g.parseTemplates().ExecuteTemplate(&b, name, data); err != nil {
+		var f failure
+		if errors.As(err, &f) {
+			// raised by a template function, e.g. on a type the target language cannot hold
+			panic(f)
+		}
+		g.Error(err, "rendering", name)
+	}
+	return strings.Trim(b.String(), "\n")
+}
+
+// render prints the output of the template at the current indentation
+func (g *Generator) render(name string, data interface{}) {
+	for _, line := range strings.Split(g.execute(name, data), "\n") {
+		if line == "" {
+			g.Newline()
+		} else {
+			g.P(line)
+		}
+	}
+}
+
+// capture returns what fn prints without indentation instead of printing it,
+// for the parts of the templates still printed with g.P, e.g. by plugins
+func (g *Generator) capture(fn func()) string {
+	buf, indent, lineEnding := g.Buffer, g.indent, g.LineEnding
+	g.Buffer, g.indent, g.LineEnding = new(bytes.Buffer), "", "\n"
+	defer func() {
+		g.Buffer, g.indent, g.LineEnding = buf, indent, lineEnding
+	}()
+	fn()
+	return strings.TrimRight(g.String(), "\n")
+}
+
+// indentLines indents the non-empty lines of the text by n tab stops
+func indentLines(n int, text string) string {
+	prefix := strings.Repeat(DefaultIndent, n)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
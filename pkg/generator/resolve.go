@@ -0,0 +1,78 @@
+package generator
+
+import "strings"
+
+// resolvedType holds what the emitters refer to an object by. It is resolved once per object, since the fields
+// of large schemas refer to the same types over and over.
+type resolvedType struct {
+	beanNames []string      // Bean class names of the object and of the messages enclosing it, outermost first
+	beanRoot  string        // Fully-qualified name of the outermost bean class containing the object
+	pbClass   string        // Fully-qualified name of the protobuf-java class
+	converter string        // Fully-qualified name of the converter of the file of the object
+	refs      []*Descriptor // Messages held by the converted fields of a message, see isRecursive
+}
+
+// resolve returns the resolved names of the object, computed on first use.
+// The names depend on the parameters and the config file, the objects are resolved once the options are read.
+func (g *Generator) resolve(obj Object) *resolvedType {
+	if t, ok := g.resolved[obj]; ok {
+		return t
+	}
+	if g.resolved == nil {
+		g.resolved = make(map[Object]*resolvedType)
+	}
+	t := &resolvedType{
+		beanNames: g.resolveBeanNames(obj),
+		pbClass:   protoJavaClassName(obj),
+		converter: g.converterPackage(obj.File()) + "." + javaConverterName(obj.File()),
+	}
+	t.beanRoot = obj.JavaImportPath().String() + "." + t.beanNames[0]
+	if d, ok := obj.(*Descriptor); ok {
+		for _, field := range d.Field {
+			if !isMessage(field) || isAnyField(field) || !g.isFieldConverted(field) {
+				continue
+			}
+			if sub, ok := g.ObjectNamed(field.GetTypeName()).(*Descriptor); ok {
+				t.refs = append(t.refs, sub)
+			}
+		}
+	}
+	g.resolved[obj] = t
+	return t
+}
+
+// resolveBeanNames returns the bean class names of the object and of the messages enclosing it, outermost first,
+// applying the prefix, suffix and name overrides
+func (g *Generator) resolveBeanNames(obj Object) []string {
+	typeName := obj.TypeName()
+	fullName := obj.File().GetPackage()
+	s := make([]string, len(typeName))
+	for i, name := range typeName {
+		if fullName != "" {
+			fullName += "."
+		}
+		fullName += name
+		if o, ok := g.MessageOverrides[fullName]; ok && o.Name != "" {
+			s[i] = o.Name
+		} else {
+			s[i] = g.BeanPrefix + name + g.BeanSuffix
+		}
+	}
+	return s
+}
+
+// pbClassName returns the fully-qualified name of the protobuf-java class of the object
+func (g *Generator) pbClassName(obj Object) string {
+	return g.resolve(obj).pbClass
+}
+
+// converterClassRef returns the name referring to the converter of the file of the object from the java package,
+// converters living in other packages are fully qualified
+func (g *Generator) converterClassRef(thisPackage string, obj Object) string {
+	conv := g.resolve(obj).converter
+	i := strings.LastIndexByte(conv, '.')
+	if conv[:i] == thisPackage {
+		return conv[i+1:]
+	}
+	return conv
+}
@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// Options configures Run, the settings are applied on top of the parameter of the request,
+// which is parsed like the parameter passed to --bean_out.
+type Options struct {
+	Lang       string            // Target language, kotlin or java, same as lang
+	Package    string            // Java package of the beans, same as vopkg
+	ImportMap  map[string]string // Java packages of the beans by proto file, same as M<file>=<package>
+	PackageMap map[string]string // Java packages of the beans by proto package, same as pkgmap
+	BeanPrefix string            // Prepended to the names of the generated classes, same as bean_prefix
+	BeanSuffix string            // Appended to the names of the generated classes, same as bean_suffix
+
+	Plugins []Plugin // Plugins extending the beans, run along with the registered plugins listed in the plugins parameter
+
+	// Stream receives each generated file as soon as it is complete, instead of the File field of the response,
+	// so that memory scales with the largest file rather than the whole output. Files streamed before a failure
+	// are not taken back, the error is returned as usual. An error of Stream aborts the generation.
+	Stream func(f *plugin.CodeGeneratorResponse_File) error
+
+	Version string    // Version of the generator, expanded in custom headers
+	Log     io.Writer // Diagnostics of the generation, discarded when nil
+}
+
+// parameter returns the parameter of the request followed by the options,
+// so that the options take precedence.
+func (o Options) parameter(requestParameter string) string {
+	params := make([]string, 0)
+	if requestParameter != "" {
+		params = append(params, requestParameter)
+	}
+	add := func(k, v string) {
+		if v != "" {
+			params = append(params, k+"="+v)
+		}
+	}
+	add("lang", o.Lang)
+	add("vopkg", o.Package)
+	add("bean_prefix", o.BeanPrefix)
+	add("bean_suffix", o.BeanSuffix)
+	for _, file := range sortedKeys(stringSet(o.ImportMap)) {
+		add("M"+file, o.ImportMap[file])
+	}
+	if len(o.PackageMap) > 0 {
+		mappings := make([]string, 0, len(o.PackageMap))
+		for _, pkg := range sortedKeys(stringSet(o.PackageMap)) {
+			mappings = append(mappings, pkg+":"+o.PackageMap[pkg])
+		}
+		add("pkgmap", strings.Join(mappings, ";"))
+	}
+	return strings.Join(params, ",")
+}
+
+// Run generates the beans and converters of the request, it is the library entry point of the plugin
+// for tools embedding the generation, such as IDE plugins or build servers.
+// A failure is returned as an error, a *SchemaError when caused by the proto schema,
+// the response then carries the same message in its Error field and can still be sent to protoc as is.
+func Run(req *plugin.CodeGeneratorRequest, opts Options) (*plugin.CodeGeneratorResponse, error) {
+	return RunContext(context.Background(), req, opts)
+}
+
+// RunContext is Run stopping once the context is canceled or past its deadline,
+// the error then wraps the error of the context, e.g. context.DeadlineExceeded.
+func RunContext(ctx context.Context, req *plugin.CodeGeneratorRequest, opts Options) (*plugin.CodeGeneratorResponse, error) {
+	g := New()
+	g.ctx = ctx
+	g.stream = opts.Stream
+	if req != nil {
+		g.Request = req
+	}
+	g.Version = opts.Version
+	if opts.Log != nil {
+		g.logger = newLogger(opts.Log)
+	} else {
+		g.logger = newLogger(ioutil.Discard)
+	}
+
+	func() {
+		defer g.HandleFailure()
+		g.generate(opts.parameter(g.Request.GetParameter()), opts.Plugins...)
+	}()
+
+	return g.Response, g.err
+}
+
+// generate runs the generator over the request with the parameter, the plugins run along with those enabled by the parameter
+func (g *Generator) generate(parameter string, plugins ...Plugin) {
+	if len(g.Request.FileToGenerate) == 0 {
+		g.Fail("no files to generate")
+	}
+
+	g.CommandLineParameters(parameter)
+	g.checkCompilerVersion()
+	g.plugins = append(g.plugins, plugins...)
+
+	// Save the request for troubleshooting, it can be replayed by piping the file into the plugin
+	if name := g.Param["debug_dump"]; name != "" {
+		data, err := proto.Marshal(g.Request)
+		if err != nil {
+			g.Error(err, "failed to marshal request")
+		}
+		if err = ioutil.WriteFile(name, data, 0644); err != nil {
+			g.Error(err, "failed to dump request")
+		}
+	}
+
+	// Create a wrapped version of the Descriptors and EnumDescriptors that
+	// point to the file that defines them.
+	g.WrapTypes()
+	g.BuildTypeNameMap()
+	g.initPlugins()
+
+	g.GenerateAllFiles()
+}
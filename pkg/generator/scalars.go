@@ -0,0 +1,47 @@
+package generator
+
+import "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+// The types of the singular numbers and booleans of the beans, chosen by the scalars parameter
+const (
+	scalarsPrimitive = "primitive" // int, boolean and Int, Boolean in kotlin, zero until set, the default
+	scalarsBoxed     = "boxed"     // Integer, Boolean and Int?, Boolean? in kotlin, null until set
+)
+
+// parseScalars validates the scalars parameter
+func (g *Generator) parseScalars(v string) string {
+	if v != scalarsPrimitive && v != scalarsBoxed {
+		g.Fail("invalid scalars", v+", use primitive or boxed")
+	}
+	return v
+}
+
+// isBoxedScalar reports whether the property of a singular number or boolean is boxed and null until set
+// with scalars=boxed. Members of oneofs and proto3 optional fields are boxed whatever the parameter,
+// strings and byte arrays are references already. The scalars of proto2 required fields, strings and byte
+// arrays included, are null until set whatever the parameter, so that toPb tells a missing value from a zero.
+func (g *Generator) isBoxedScalar(f *JavaField) bool {
+	if f.Repeated || f.Oneof != nil || f.Value.Kind != ScalarKind {
+		return false
+	}
+	if isRequired(f.Proto) {
+		return true
+	}
+	if g.Scalars != scalarsBoxed {
+		return false
+	}
+	t := f.Proto.GetType()
+	return t != descriptor.FieldDescriptorProto_TYPE_STRING && t != descriptor.FieldDescriptorProto_TYPE_BYTES
+}
+
+// isNullScalar reports whether the property of a singular scalar is null until set, as members of oneofs and boxed
+// scalars are
+func (g *Generator) isNullScalar(f *JavaField) bool {
+	return f.Value.Kind == ScalarKind && !f.Repeated && (f.Oneof != nil || g.isBoxedScalar(f))
+}
+
+// hasPresence reports whether the converters set the boxed scalar only when present in the protobuf message,
+// leaving it null otherwise, which takes the explicit presence of proto2 fields
+func (g *Generator) hasPresence(c *JavaClass, f *JavaField) bool {
+	return g.isBoxedScalar(f) && !c.Desc.proto3()
+}
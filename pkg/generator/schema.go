@@ -0,0 +1,228 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"path"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// jsonSchemaDialect is the JSON Schema draft the schemas of json_schema=true are written in
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchemaExt is appended to the name of a top-level bean to name the file of the schema of its message
+const jsonSchemaExt = ".schema.json"
+
+// schemaObject is a JSON object keeping its members in order, the properties of a message in the order of the bean
+type schemaObject []schemaMember
+
+// schemaMember is a member of a schemaObject
+type schemaMember struct {
+	Key   string
+	Value interface{}
+}
+
+// MarshalJSON writes the members in order
+func (o schemaObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if err := marshalSchema(&b, m.Key, ""); err != nil {
+			return nil, err
+		}
+		b.WriteByte(':')
+		if err := marshalSchema(&b, m.Value, ""); err != nil {
+			return nil, err
+		}
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// marshalSchema writes the value as JSON indented by the indent, leaving the comments of the proto files unescaped,
+// e.g. the < and > of "List<String>", and without a trailing newline
+func marshalSchema(b *bytes.Buffer, v interface{}, indent string) error {
+	enc := json.NewEncoder(b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	b.Truncate(b.Len() - 1)
+	return nil
+}
+
+// wellKnownSchemas are the schemas of the well-known types of special JSON representations,
+// by full name, wrappers are the schemas of the values they wrap
+var wellKnownSchemas = map[string]schemaObject{
+	"google.protobuf.Any": {
+		{"type", "object"},
+		{"properties", schemaObject{{"@type", schemaObject{{"type", "string"}}}}},
+		{"required", []string{"@type"}},
+	},
+	"google.protobuf.Timestamp": {{"type", "string"}, {"format", "date-time"}},
+	"google.protobuf.Duration":  {{"type", "string"}, {"pattern", `^-?[0-9]+(\.[0-9]{1,9})?s$`}},
+	"google.protobuf.FieldMask": {{"type", "string"}},
+	"google.protobuf.Struct":    {{"type", "object"}},
+	"google.protobuf.Value":     {},
+	"google.protobuf.ListValue": {{"type", "array"}},
+}
+
+// jsonSchema collects the definitions of the messages and enums of a schema, in the order they are referred to
+type jsonSchema struct {
+	g     *Generator
+	names []string
+	defs  map[string]schemaObject
+}
+
+// generateJSONSchema writes the JSON Schema of the top-level message next to its bean, the messages and enums
+// it refers to are defined in the same document
+func (g *Generator) generateJSONSchema(file *FileDescriptor, d *Descriptor) {
+	s := &jsonSchema{g: g, defs: make(map[string]schemaObject)}
+	root := s.ref(d)[0].Value
+	defs := make(schemaObject, 0, len(s.names))
+	for _, name := range s.names {
+		defs = append(defs, schemaMember{name, s.defs[name]})
+	}
+	var data bytes.Buffer
+	err := marshalSchema(&data, schemaObject{
+		{"$schema", jsonSchemaDialect},
+		{"title", protoFullName(d)},
+		{"$ref", root},
+		{"$defs", defs},
+	}, "  ")
+	if err != nil {
+		g.Error(err, "failed to marshal the json schema of", protoFullName(d))
+	}
+	name := g.outputFileName(file, descriptorPackagePath(g, d), g.beanName(d))
+	name = strings.TrimSuffix(name, path.Ext(name)) + jsonSchemaExt
+	g.addFile(name, strings.ReplaceAll(data.String()+"\n", "\n", g.LineEnding), []*FileDescriptor{file}, []Object{d})
+}
+
+// ref returns the reference to the definition of the message or enum, defining it first
+func (s *jsonSchema) ref(o Object) schemaObject {
+	name := protoFullName(o)
+	if _, ok := s.defs[name]; !ok {
+		s.names = append(s.names, name)
+		// defined before its members, which may refer to it
+		s.defs[name] = nil
+		switch o := o.(type) {
+		case *Descriptor:
+			s.defs[name] = s.message(o)
+		case *EnumDescriptor:
+			s.defs[name] = s.enum(o)
+		}
+	}
+	return schemaObject{{"$ref", "#/$defs/" + name}}
+}
+
+// message returns the definition of the message, its properties named after the json names of the fields of its bean
+func (s *jsonSchema) message(d *Descriptor) schemaObject {
+	c := s.g.javaClass(d)
+	def := schemaObject{{"title", protoFullName(d)}}
+	if text := schemaDescription(d.File(), c.Path); text != "" {
+		def = append(def, schemaMember{"description", text})
+	}
+	def = append(def, schemaMember{"type", "object"})
+	props := make(schemaObject, 0, len(c.Fields))
+	var required []string
+	for _, f := range c.Fields {
+		jsonName := f.Proto.GetJsonName()
+		if jsonName == "" {
+			jsonName = defaultJSONName(f.Proto.GetName())
+		}
+		prop := s.field(f.Proto)
+		if text := schemaDescription(d.File(), f.Path); text != "" {
+			prop = append(schemaObject{{"description", text}}, prop...)
+		}
+		props = append(props, schemaMember{jsonName, prop})
+		if isRequired(f.Proto) {
+			required = append(required, jsonName)
+		}
+	}
+	def = append(def, schemaMember{"properties", props})
+	if len(required) > 0 {
+		def = append(def, schemaMember{"required", required})
+	}
+	return def
+}
+
+// enum returns the definition of the enum, the names of its values as printed in JSON
+func (s *jsonSchema) enum(e *EnumDescriptor) schemaObject {
+	names := make([]string, 0, len(e.Value))
+	for _, v := range e.Value {
+		names = append(names, v.GetName())
+	}
+	def := schemaObject{{"title", protoFullName(e)}}
+	if text := schemaDescription(e.File(), e.path); text != "" {
+		def = append(def, schemaMember{"description", text})
+	}
+	return append(def, schemaMember{"type", "string"}, schemaMember{"enum", names})
+}
+
+// field returns the schema of the values of the field, arrays of lists and objects of maps
+func (s *jsonSchema) field(field *descriptor.FieldDescriptorProto) schemaObject {
+	if entry := s.g.mapEntryOf(field); entry != nil {
+		return schemaObject{{"type", "object"}, {"additionalProperties", s.value(entry.Field[1])}}
+	}
+	if isRepeated(field) {
+		return schemaObject{{"type", "array"}, {"items", s.value(field)}}
+	}
+	return s.value(field)
+}
+
+// value returns the schema of a single value of the field, following the JSON mapping of proto3:
+// 64-bit integers may be strings, bytes are Base64 strings and the well-known types have their own representations
+func (s *jsonSchema) value(field *descriptor.FieldDescriptorProto) schemaObject {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE, descriptor.FieldDescriptorProto_TYPE_GROUP:
+		name := strings.TrimPrefix(field.GetTypeName(), ".")
+		if schema, ok := wellKnownSchemas[name]; ok {
+			return schema
+		}
+		d := s.g.ObjectNamed(field.GetTypeName()).(*Descriptor)
+		if strings.HasPrefix(name, "google.protobuf.") && strings.HasSuffix(name, "Value") && len(d.Field) == 1 {
+			// wrappers
+			return s.value(d.Field[0])
+		}
+		return s.ref(d)
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		return s.ref(s.g.ObjectNamed(field.GetTypeName()))
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return schemaObject{{"type", "string"}}
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return schemaObject{{"type", "string"}, {"contentEncoding", "base64"}}
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return schemaObject{{"type", "boolean"}}
+	case descriptor.FieldDescriptorProto_TYPE_DOUBLE, descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		return schemaObject{{"type", "number"}}
+	case descriptor.FieldDescriptorProto_TYPE_UINT32, descriptor.FieldDescriptorProto_TYPE_FIXED32:
+		return schemaObject{{"type", "integer"}, {"minimum", 0}}
+	case descriptor.FieldDescriptorProto_TYPE_INT64, descriptor.FieldDescriptorProto_TYPE_SINT64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		return schemaObject{{"type", []string{"integer", "string"}}, {"pattern", "^-?[0-9]+$"}}
+	case descriptor.FieldDescriptorProto_TYPE_UINT64, descriptor.FieldDescriptorProto_TYPE_FIXED64:
+		return schemaObject{{"type", []string{"integer", "string"}}, {"minimum", 0}, {"pattern", "^[0-9]+$"}}
+	}
+	return schemaObject{{"type", "integer"}}
+}
+
+// schemaDescription returns the leading comments of the element of the path, directives excluded
+func schemaDescription(file *FileDescriptor, path string) string {
+	loc, ok := file.locations[path]
+	if !ok {
+		return ""
+	}
+	var lines []string
+	for _, line := range strings.Split(loc.GetLeadingComments(), "\n") {
+		if !isDirective(line) {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
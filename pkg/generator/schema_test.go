@@ -0,0 +1,57 @@
+package generator_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	resp := runFixtures(t, "lang=java,json_schema=true")
+	type schema struct {
+		Ref         string                     `json:"$ref"`
+		Description string                     `json:"description"`
+		Properties  map[string]json.RawMessage `json:"properties"`
+		Required    []string                   `json:"required"`
+		Enum        []string                   `json:"enum"`
+		Defs        map[string]schema          `json:"$defs"`
+	}
+	docs := make(map[string]schema)
+	for _, f := range resp.File {
+		if strings.HasSuffix(f.GetName(), ".schema.json") {
+			var doc schema
+			if err := json.Unmarshal([]byte(f.GetContent()), &doc); err != nil {
+				t.Fatalf("%s: %v", f.GetName(), err)
+			}
+			docs[f.GetName()] = doc
+		}
+	}
+
+	order, ok := docs["com/example/shop/order/vo/Order.schema.json"]
+	if !ok {
+		t.Fatalf("no Order.schema.json generated, got %d schemas", len(docs))
+	}
+	if order.Ref != "#/$defs/shop.order.Order" {
+		t.Errorf("Order.schema.json refers to %s", order.Ref)
+	}
+	def := order.Defs["shop.order.Order"]
+	if def.Description != "An order placed by a customer" {
+		t.Errorf("Order has description %q", def.Description)
+	}
+	for _, name := range []string{"itemsByLine", "cardToken", "total"} {
+		if _, ok := def.Properties[name]; !ok {
+			t.Errorf("Order has no property %s", name)
+		}
+	}
+	if state := order.Defs["shop.order.Order.State"]; strings.Join(state.Enum, ",") != "STATE_UNKNOWN,PLACED,SHIPPED" {
+		t.Errorf("Order.State has values %v", state.Enum)
+	}
+	if _, ok := order.Defs["shop.common.Money"]; !ok {
+		t.Error("Order.schema.json does not define shop.common.Money")
+	}
+
+	legacy := docs["com/example/shop/legacy/vo/Stock.schema.json"]
+	if stock := legacy.Defs["shop.legacy.Stock"]; strings.Join(stock.Required, ",") != "sku" {
+		t.Errorf("Stock requires %v", stock.Required)
+	}
+}
@@ -0,0 +1,222 @@
+package com.example.shop.order.vo.converter;
+
+// Code generated by protoc-gen-bean. DO NOT EDIT.
+//
+//     shop/order.proto
+//
+
+import com.example.shop.common.vo.Currency;
+import com.example.shop.order.vo.Order;
+
+public final class ShopOrderPb2JavaBean {
+    private ShopOrderPb2JavaBean() {
+    }
+
+    public static Order.State toBean(com.example.shop.order.OrderOuterClass.Order.State pb) {
+        if (pb == com.example.shop.order.OrderOuterClass.Order.State.UNRECOGNIZED) {
+            return Order.State.forNumber(-1);
+        }
+        return Order.State.forNumber(pb.getNumber());
+    }
+
+    public static com.example.shop.order.OrderOuterClass.Order.State toPb(Order.State bean) {
+        com.example.shop.order.OrderOuterClass.Order.State pb = com.example.shop.order.OrderOuterClass.Order.State.forNumber(bean.getCode());
+        return pb != null ? pb : com.example.shop.order.OrderOuterClass.Order.State.values()[0];
+    }
+
+    public static Order toBean(com.example.shop.order.OrderOuterClass.Order pb) {
+        Order bean = new Order();
+        bean.setId(pb.getId());
+        bean.setState(toBean(pb.getState()));
+        for (com.example.shop.order.OrderOuterClass.Order.Item v : pb.getItemsList()) {
+            bean.getItems().add(toBean(v));
+        }
+        bean.getLabels().putAll(pb.getLabelsMap());
+        for (java.util.Map.Entry<Integer, com.example.shop.order.OrderOuterClass.Order.Item> e : pb.getItemsByLineMap().entrySet()) {
+            bean.getItemsByLine().put(e.getKey(), toBean(e.getValue()));
+        }
+        bean.setSignature(pb.getSignature().toByteArray());
+        if (pb.hasNote()) {
+            bean.setNote(pb.getNote());
+            bean.setNoteCase(Order.NoteCase.NOTE);
+        }
+        switch (pb.getPaymentCase()) {
+            case CARD_TOKEN:
+                bean.setCardToken(pb.getCardToken());
+                break;
+            case VOUCHER_CODE:
+                bean.setVoucherCode(pb.getVoucherCode());
+                break;
+            default:
+                break;
+        }
+        bean.setPaymentCase(Order.PaymentCase.forNumber(pb.getPaymentCase().getNumber()));
+        if (pb.hasTotal()) {
+            bean.setTotal(com.example.shop.common.vo.converter.CommonProtoPb2JavaBean.toBean(pb.getTotal()));
+        }
+        for (com.example.shop.common.CommonProto.Currency v : pb.getAcceptedList()) {
+            bean.getAccepted().add(com.example.shop.common.vo.converter.CommonProtoPb2JavaBean.toBean(v));
+        }
+        return bean;
+    }
+
+    public static com.example.shop.order.OrderOuterClass.Order toPb(Order bean) {
+        com.example.shop.order.OrderOuterClass.Order.Builder builder = com.example.shop.order.OrderOuterClass.Order.newBuilder();
+        if (bean.getId() != null) {
+            builder.setId(bean.getId());
+        }
+        if (bean.getState() != null) {
+            builder.setState(toPb(bean.getState()));
+        }
+        if (bean.getItems() != null) {
+            for (Order.Item v : bean.getItems()) {
+                builder.addItems(toPb(v));
+            }
+        }
+        if (bean.getLabels() != null) {
+            builder.putAllLabels(bean.getLabels());
+        }
+        if (bean.getItemsByLine() != null) {
+            for (java.util.Map.Entry<Integer, Order.Item> e : bean.getItemsByLine().entrySet()) {
+                builder.putItemsByLine(e.getKey(), toPb(e.getValue()));
+            }
+        }
+        if (bean.getSignature() != null) {
+            builder.setSignature(com.google.protobuf.ByteString.copyFrom(bean.getSignature()));
+        }
+        if (bean.getNote() != null) {
+            builder.setNote(bean.getNote());
+        }
+        switch (bean.getPaymentCase()) {
+            case CARD_TOKEN:
+                if (bean.getCardToken() != null) {
+                    builder.setCardToken(bean.getCardToken());
+                }
+                break;
+            case VOUCHER_CODE:
+                if (bean.getVoucherCode() != null) {
+                    builder.setVoucherCode(bean.getVoucherCode());
+                }
+                break;
+            default:
+                break;
+        }
+        if (bean.getTotal() != null) {
+            builder.setTotal(com.example.shop.common.vo.converter.CommonProtoPb2JavaBean.toPb(bean.getTotal()));
+        }
+        if (bean.getAccepted() != null) {
+            for (Currency v : bean.getAccepted()) {
+                builder.addAccepted(com.example.shop.common.vo.converter.CommonProtoPb2JavaBean.toPb(v));
+            }
+        }
+        return builder.build();
+    }
+
+    public static Order toOrder(byte[] data) throws com.google.protobuf.InvalidProtocolBufferException {
+        return toBean(com.example.shop.order.OrderOuterClass.Order.parseFrom(data));
+    }
+
+    public static byte[] toByteArray(Order bean) {
+        return toPb(bean).toByteArray();
+    }
+
+    /**
+     * Decodes the message serialized and encoded in Base64, see {@link java.util.Base64#getDecoder}.
+     */
+    public static Order toOrderFromBase64(String base64) throws com.google.protobuf.InvalidProtocolBufferException {
+        return toOrder(java.util.Base64.getDecoder().decode(base64));
+    }
+
+    /**
+     * Returns the message serialized and encoded in Base64, see {@link java.util.Base64#getEncoder}.
+     */
+    public static String toBase64(Order bean) {
+        return java.util.Base64.getEncoder().encodeToString(toByteArray(bean));
+    }
+
+    public static Order toOrder(java.io.InputStream input) throws java.io.IOException {
+        return toBean(com.example.shop.order.OrderOuterClass.Order.parseFrom(input));
+    }
+
+    /**
+     * Reads the next length-delimited message from the stream, returns null at the end of the stream.
+     */
+    public static Order readDelimitedOrder(java.io.InputStream input) throws java.io.IOException {
+        com.example.shop.order.OrderOuterClass.Order pb = com.example.shop.order.OrderOuterClass.Order.parseDelimitedFrom(input);
+        return pb != null ? toBean(pb) : null;
+    }
+
+    public static void writeTo(Order bean, java.io.OutputStream output) throws java.io.IOException {
+        toPb(bean).writeTo(output);
+    }
+
+    public static void writeDelimitedTo(Order bean, java.io.OutputStream output) throws java.io.IOException {
+        toPb(bean).writeDelimitedTo(output);
+    }
+
+    public static Order.Item toBean(com.example.shop.order.OrderOuterClass.Order.Item pb) {
+        Order.Item bean = new Order.Item();
+        bean.setSku(pb.getSku());
+        bean.setQuantity(pb.getQuantity());
+        if (pb.hasPrice()) {
+            bean.setPrice(com.example.shop.common.vo.converter.CommonProtoPb2JavaBean.toBean(pb.getPrice()));
+        }
+        return bean;
+    }
+
+    public static com.example.shop.order.OrderOuterClass.Order.Item toPb(Order.Item bean) {
+        com.example.shop.order.OrderOuterClass.Order.Item.Builder builder = com.example.shop.order.OrderOuterClass.Order.Item.newBuilder();
+        if (bean.getSku() != null) {
+            builder.setSku(bean.getSku());
+        }
+        builder.setQuantity(bean.getQuantity());
+        if (bean.getPrice() != null) {
+            builder.setPrice(com.example.shop.common.vo.converter.CommonProtoPb2JavaBean.toPb(bean.getPrice()));
+        }
+        return builder.build();
+    }
+
+    public static Order.Item toOrderItem(byte[] data) throws com.google.protobuf.InvalidProtocolBufferException {
+        return toBean(com.example.shop.order.OrderOuterClass.Order.Item.parseFrom(data));
+    }
+
+    public static byte[] toByteArray(Order.Item bean) {
+        return toPb(bean).toByteArray();
+    }
+
+    /**
+     * Decodes the message serialized and encoded in Base64, see {@link java.util.Base64#getDecoder}.
+     */
+    public static Order.Item toOrderItemFromBase64(String base64) throws com.google.protobuf.InvalidProtocolBufferException {
+        return toOrderItem(java.util.Base64.getDecoder().decode(base64));
+    }
+
+    /**
+     * Returns the message serialized and encoded in Base64, see {@link java.util.Base64#getEncoder}.
+     */
+    public static String toBase64(Order.Item bean) {
+        return java.util.Base64.getEncoder().encodeToString(toByteArray(bean));
+    }
+
+    public static Order.Item toOrderItem(java.io.InputStream input) throws java.io.IOException {
+        return toBean(com.example.shop.order.OrderOuterClass.Order.Item.parseFrom(input));
+    }
+
+    /**
+     * Reads the next length-delimited message from the stream, returns null at the end of the stream.
+     */
+    public static Order.Item readDelimitedOrderItem(java.io.InputStream input) throws java.io.IOException {
+        com.example.shop.order.OrderOuterClass.Order.Item pb = com.example.shop.order.OrderOuterClass.Order.Item.parseDelimitedFrom(input);
+        return pb != null ? toBean(pb) : null;
+    }
+
+    public static void writeTo(Order.Item bean, java.io.OutputStream output) throws java.io.IOException {
+        toPb(bean).writeTo(output);
+    }
+
+    public static void writeDelimitedTo(Order.Item bean, java.io.OutputStream output) throws java.io.IOException {
+        toPb(bean).writeDelimitedTo(output);
+    }
+
+    // @@protoc_insertion_point(converter_scope:shop/order.proto)
+}
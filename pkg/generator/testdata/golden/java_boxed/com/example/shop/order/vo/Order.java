@@ -0,0 +1,326 @@
+package com.example.shop.order.vo;
+
+// Code generated by protoc-gen-bean. DO NOT EDIT.
+//
+//     shop/order.proto
+//
+
+import com.example.shop.common.vo.Currency;
+import com.example.shop.common.vo.Money;
+
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+
+// An order placed by a customer
+public class Order {
+    private String id = "";
+    private Order.State state = null;
+    private List<Order.Item> items = new ArrayList<>();
+    private Map<String, String> labels = new HashMap<>();
+    private Map<Integer, Order.Item> itemsByLine = new HashMap<>();
+    private byte[] signature = new byte[]{};
+    private String note = null;
+    private String cardToken = null;
+    private String voucherCode = null;
+    private Money total = null;
+    private List<Currency> accepted = new ArrayList<>();
+
+    public enum PaymentCase {
+        CARD_TOKEN(8),
+        VOUCHER_CODE(9),
+        PAYMENT_NOT_SET(0);
+
+        private final int code;
+
+        PaymentCase(int code) {
+            this.code = code;
+        }
+
+        public int getCode() {
+            return code;
+        }
+
+        public static PaymentCase forNumber(int value) {
+            switch (value) {
+                case 8:
+                    return CARD_TOKEN;
+                case 9:
+                    return VOUCHER_CODE;
+                default:
+                    return PAYMENT_NOT_SET;
+            }
+        }
+    }
+
+    private PaymentCase paymentCase = PaymentCase.PAYMENT_NOT_SET;
+
+    public enum NoteCase {
+        NOTE(7),
+        NOTE_NOT_SET(0);
+
+        private final int code;
+
+        NoteCase(int code) {
+            this.code = code;
+        }
+
+        public int getCode() {
+            return code;
+        }
+
+        public static NoteCase forNumber(int value) {
+            switch (value) {
+                case 7:
+                    return NOTE;
+                default:
+                    return NOTE_NOT_SET;
+            }
+        }
+    }
+
+    private NoteCase noteCase = NoteCase.NOTE_NOT_SET;
+
+    // State of the order
+    // Reserved value numbers: 3
+    public enum State {
+        STATE_UNKNOWN(0, "STATE_UNKNOWN"),
+        PLACED(1, "PLACED"),
+        SHIPPED(2, "SHIPPED");
+
+        private final int code;
+        private final String protoName;
+
+        State(int code, String protoName) {
+            this.code = code;
+            this.protoName = protoName;
+        }
+
+        public int getCode() {
+            return code;
+        }
+
+        /**
+         * Returns the name of the value in the proto file.
+         */
+        public String getProtoName() {
+            return protoName;
+        }
+
+        /**
+         * @deprecated Use {@link #forNumber(int)} instead.
+         */
+        @java.lang.Deprecated
+        public static State valueOf(int value) {
+            return forNumber(value);
+        }
+
+        public static State forNumber(int value) {
+            switch (value) {
+                case 1:
+                    return PLACED;
+                case 2:
+                    return SHIPPED;
+                default:
+                    return STATE_UNKNOWN;
+            }
+        }
+
+        public static State fromName(String name) {
+            if (name == null) {
+                return STATE_UNKNOWN;
+            }
+            switch (name) {
+                case "STATE_UNKNOWN":
+                    return STATE_UNKNOWN;
+                case "PLACED":
+                    return PLACED;
+                case "SHIPPED":
+                    return SHIPPED;
+                default:
+                    return STATE_UNKNOWN;
+            }
+        }
+
+        // @@protoc_insertion_point(enum_scope:shop.order.Order.State)
+    }
+
+    // A line of the order
+    public static class Item {
+        private String sku = "";
+        private Integer quantity = null;
+        private Money price = null;
+
+        public String getSku() {
+            return sku;
+        }
+
+        public void setSku(String sku) {
+            this.sku = sku;
+        }
+
+        public Integer getQuantity() {
+            return quantity;
+        }
+
+        public void setQuantity(Integer quantity) {
+            this.quantity = quantity;
+        }
+
+        public Money getPrice() {
+            return price;
+        }
+
+        public void setPrice(Money price) {
+            this.price = price;
+        }
+
+        @Override
+        public String toString() {
+            StringBuilder sb = new StringBuilder("Item{");
+            sb.append("sku='").append(sku).append('\'').append(", quantity=").append(quantity);
+            sb.append(", price=").append(price);
+            return sb.append('}').toString();
+        }
+
+        // @@protoc_insertion_point(class_scope:shop.order.Order.Item)
+    }
+
+    public String getId() {
+        return id;
+    }
+
+    public void setId(String id) {
+        this.id = id;
+    }
+
+    public Order.State getState() {
+        return state;
+    }
+
+    public void setState(Order.State state) {
+        this.state = state;
+    }
+
+    public List<Order.Item> getItems() {
+        return items;
+    }
+
+    public void setItems(List<Order.Item> items) {
+        this.items = items;
+    }
+
+    public Map<String, String> getLabels() {
+        return labels;
+    }
+
+    public void setLabels(Map<String, String> labels) {
+        this.labels = labels;
+    }
+
+    public Map<Integer, Order.Item> getItemsByLine() {
+        return itemsByLine;
+    }
+
+    public void setItemsByLine(Map<Integer, Order.Item> itemsByLine) {
+        this.itemsByLine = itemsByLine;
+    }
+
+    public byte[] getSignature() {
+        return signature;
+    }
+
+    public void setSignature(byte[] signature) {
+        this.signature = signature;
+    }
+
+    public String getNote() {
+        return note;
+    }
+
+    public void setNote(String note) {
+        this.note = note;
+        if (note != null) {
+            this.noteCase = NoteCase.NOTE;
+        } else if (this.noteCase == NoteCase.NOTE) {
+            this.noteCase = NoteCase.NOTE_NOT_SET;
+        }
+    }
+
+    public String getCardToken() {
+        return cardToken;
+    }
+
+    public void setCardToken(String cardToken) {
+        this.cardToken = cardToken;
+        if (cardToken != null) {
+            this.paymentCase = PaymentCase.CARD_TOKEN;
+            this.voucherCode = null;
+        } else if (this.paymentCase == PaymentCase.CARD_TOKEN) {
+            this.paymentCase = PaymentCase.PAYMENT_NOT_SET;
+        }
+    }
+
+    public String getVoucherCode() {
+        return voucherCode;
+    }
+
+    public void setVoucherCode(String voucherCode) {
+        this.voucherCode = voucherCode;
+        if (voucherCode != null) {
+            this.paymentCase = PaymentCase.VOUCHER_CODE;
+            this.cardToken = null;
+        } else if (this.paymentCase == PaymentCase.VOUCHER_CODE) {
+            this.paymentCase = PaymentCase.PAYMENT_NOT_SET;
+        }
+    }
+
+    public Money getTotal() {
+        return total;
+    }
+
+    public void setTotal(Money total) {
+        this.total = total;
+    }
+
+    public List<Currency> getAccepted() {
+        return accepted;
+    }
+
+    public void setAccepted(List<Currency> accepted) {
+        this.accepted = accepted;
+    }
+
+    public PaymentCase getPaymentCase() {
+        return paymentCase;
+    }
+
+    public void setPaymentCase(PaymentCase paymentCase) {
+        this.paymentCase = paymentCase;
+    }
+
+    public NoteCase getNoteCase() {
+        return noteCase;
+    }
+
+    public void setNoteCase(NoteCase noteCase) {
+        this.noteCase = noteCase;
+    }
+
+    @Override
+    public String toString() {
+        StringBuilder sb = new StringBuilder("Order{");
+        sb.append("id='").append(id).append('\'').append(", state=").append(state);
+        sb.append(", items=").append(items).append(", labels=").append(labels);
+        sb.append(", itemsByLine=").append(itemsByLine);
+        sb.append(", signature=").append(signature.length).append(" bytes");
+        sb.append(", note='").append(note).append('\'');
+        sb.append(", cardToken='").append(cardToken).append('\'');
+        sb.append(", voucherCode='").append(voucherCode).append('\'');
+        sb.append(", total=").append(total).append(", accepted=").append(accepted);
+        return sb.append('}').toString();
+    }
+
+    // @@protoc_insertion_point(class_scope:shop.order.Order)
+}
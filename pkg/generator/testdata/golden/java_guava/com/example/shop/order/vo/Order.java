@@ -0,0 +1,353 @@
+package com.example.shop.order.vo;
+
+// Code generated by protoc-gen-bean. DO NOT EDIT.
+//
+//     shop/order.proto
+//
+
+import com.example.shop.common.vo.Currency;
+import com.example.shop.common.vo.Money;
+
+import com.google.common.base.MoreObjects;
+import com.google.common.base.Optional;
+import com.google.common.collect.ImmutableList;
+import com.google.common.collect.ImmutableMap;
+
+import java.util.Collection;
+import java.util.LinkedHashMap;
+import java.util.Map;
+
+// An order placed by a customer
+public class Order {
+    private String id = "";
+    private Order.State state = null;
+    private ImmutableList<Order.Item> items = ImmutableList.of();
+    private ImmutableMap<String, String> labels = ImmutableMap.of();
+    private ImmutableMap<Integer, Order.Item> itemsByLine = ImmutableMap.of();
+    private byte[] signature = new byte[]{};
+    private String note = null;
+    private String cardToken = null;
+    private String voucherCode = null;
+    private Optional<Money> total = Optional.absent();
+    private ImmutableList<Currency> accepted = ImmutableList.of();
+
+    public enum PaymentCase {
+        CARD_TOKEN(8),
+        VOUCHER_CODE(9),
+        PAYMENT_NOT_SET(0);
+
+        private final int code;
+
+        PaymentCase(int code) {
+            this.code = code;
+        }
+
+        public int getCode() {
+            return code;
+        }
+
+        public static PaymentCase forNumber(int value) {
+            switch (value) {
+                case 8:
+                    return CARD_TOKEN;
+                case 9:
+                    return VOUCHER_CODE;
+                default:
+                    return PAYMENT_NOT_SET;
+            }
+        }
+    }
+
+    private PaymentCase paymentCase = PaymentCase.PAYMENT_NOT_SET;
+
+    public enum NoteCase {
+        NOTE(7),
+        NOTE_NOT_SET(0);
+
+        private final int code;
+
+        NoteCase(int code) {
+            this.code = code;
+        }
+
+        public int getCode() {
+            return code;
+        }
+
+        public static NoteCase forNumber(int value) {
+            switch (value) {
+                case 7:
+                    return NOTE;
+                default:
+                    return NOTE_NOT_SET;
+            }
+        }
+    }
+
+    private NoteCase noteCase = NoteCase.NOTE_NOT_SET;
+
+    // State of the order
+    // Reserved value numbers: 3
+    public enum State {
+        STATE_UNKNOWN(0, "STATE_UNKNOWN"),
+        PLACED(1, "PLACED"),
+        SHIPPED(2, "SHIPPED");
+
+        private final int code;
+        private final String protoName;
+
+        State(int code, String protoName) {
+            this.code = code;
+            this.protoName = protoName;
+        }
+
+        public int getCode() {
+            return code;
+        }
+
+        /**
+         * Returns the name of the value in the proto file.
+         */
+        public String getProtoName() {
+            return protoName;
+        }
+
+        /**
+         * @deprecated Use {@link #forNumber(int)} instead.
+         */
+        @java.lang.Deprecated
+        public static State valueOf(int value) {
+            return forNumber(value);
+        }
+
+        public static State forNumber(int value) {
+            switch (value) {
+                case 1:
+                    return PLACED;
+                case 2:
+                    return SHIPPED;
+                default:
+                    return STATE_UNKNOWN;
+            }
+        }
+
+        public static State fromName(String name) {
+            if (name == null) {
+                return STATE_UNKNOWN;
+            }
+            switch (name) {
+                case "STATE_UNKNOWN":
+                    return STATE_UNKNOWN;
+                case "PLACED":
+                    return PLACED;
+                case "SHIPPED":
+                    return SHIPPED;
+                default:
+                    return STATE_UNKNOWN;
+            }
+        }
+
+        // @@protoc_insertion_point(enum_scope:shop.order.Order.State)
+    }
+
+    // A line of the order
+    public static class Item {
+        private String sku = "";
+        private int quantity = 0;
+        private Optional<Money> price = Optional.absent();
+
+        public String getSku() {
+            return sku;
+        }
+
+        public void setSku(String sku) {
+            this.sku = sku;
+        }
+
+        public int getQuantity() {
+            return quantity;
+        }
+
+        public void setQuantity(int quantity) {
+            this.quantity = quantity;
+        }
+
+        public Optional<Money> getPrice() {
+            return price;
+        }
+
+        public void setPrice(Optional<Money> price) {
+            this.price = price;
+        }
+
+        @Override
+        public String toString() {
+            MoreObjects.ToStringHelper helper = MoreObjects.toStringHelper("Item");
+            helper.add("sku", sku).add("quantity", quantity).add("price", price);
+            return helper.toString();
+        }
+
+        // @@protoc_insertion_point(class_scope:shop.order.Order.Item)
+    }
+
+    public String getId() {
+        return id;
+    }
+
+    public void setId(String id) {
+        this.id = id;
+    }
+
+    public Order.State getState() {
+        return state;
+    }
+
+    public void setState(Order.State state) {
+        this.state = state;
+    }
+
+    public ImmutableList<Order.Item> getItems() {
+        return items;
+    }
+
+    public void setItems(ImmutableList<Order.Item> items) {
+        this.items = items;
+    }
+
+    public ImmutableMap<String, String> getLabels() {
+        return labels;
+    }
+
+    public void setLabels(ImmutableMap<String, String> labels) {
+        this.labels = labels;
+    }
+
+    public ImmutableMap<Integer, Order.Item> getItemsByLine() {
+        return itemsByLine;
+    }
+
+    public void setItemsByLine(ImmutableMap<Integer, Order.Item> itemsByLine) {
+        this.itemsByLine = itemsByLine;
+    }
+
+    public byte[] getSignature() {
+        return signature;
+    }
+
+    public void setSignature(byte[] signature) {
+        this.signature = signature;
+    }
+
+    public String getNote() {
+        return note;
+    }
+
+    public void setNote(String note) {
+        this.note = note;
+        if (note != null) {
+            this.noteCase = NoteCase.NOTE;
+        } else if (this.noteCase == NoteCase.NOTE) {
+            this.noteCase = NoteCase.NOTE_NOT_SET;
+        }
+    }
+
+    public String getCardToken() {
+        return cardToken;
+    }
+
+    public void setCardToken(String cardToken) {
+        this.cardToken = cardToken;
+        if (cardToken != null) {
+            this.paymentCase = PaymentCase.CARD_TOKEN;
+            this.voucherCode = null;
+        } else if (this.paymentCase == PaymentCase.CARD_TOKEN) {
+            this.paymentCase = PaymentCase.PAYMENT_NOT_SET;
+        }
+    }
+
+    public String getVoucherCode() {
+        return voucherCode;
+    }
+
+    public void setVoucherCode(String voucherCode) {
+        this.voucherCode = voucherCode;
+        if (voucherCode != null) {
+            this.paymentCase = PaymentCase.VOUCHER_CODE;
+            this.cardToken = null;
+        } else if (this.paymentCase == PaymentCase.VOUCHER_CODE) {
+            this.paymentCase = PaymentCase.PAYMENT_NOT_SET;
+        }
+    }
+
+    public Optional<Money> getTotal() {
+        return total;
+    }
+
+    public void setTotal(Optional<Money> total) {
+        this.total = total;
+    }
+
+    public ImmutableList<Currency> getAccepted() {
+        return accepted;
+    }
+
+    public void setAccepted(ImmutableList<Currency> accepted) {
+        this.accepted = accepted;
+    }
+
+    public void addItem(Order.Item value) {
+        this.items = ImmutableList.<Order.Item>builder().addAll(this.items).add(value).build();
+    }
+
+    public void addAllItems(Collection<? extends Order.Item> values) {
+        this.items = ImmutableList.<Order.Item>builder().addAll(this.items).addAll(values).build();
+    }
+
+    public void putLabel(String key, String value) {
+        Map<String, String> copy = new LinkedHashMap<>(this.labels);
+        copy.put(key, value);
+        this.labels = ImmutableMap.copyOf(copy);
+    }
+
+    public void putItemsByLine(Integer key, Order.Item value) {
+        Map<Integer, Order.Item> copy = new LinkedHashMap<>(this.itemsByLine);
+        copy.put(key, value);
+        this.itemsByLine = ImmutableMap.copyOf(copy);
+    }
+
+    public void addAccepted(Currency value) {
+        this.accepted = ImmutableList.<Currency>builder().addAll(this.accepted).add(value).build();
+    }
+
+    public void addAllAccepted(Collection<? extends Currency> values) {
+        this.accepted = ImmutableList.<Currency>builder().addAll(this.accepted).addAll(values).build();
+    }
+
+    public PaymentCase getPaymentCase() {
+        return paymentCase;
+    }
+
+    public void setPaymentCase(PaymentCase paymentCase) {
+        this.paymentCase = paymentCase;
+    }
+
+    public NoteCase getNoteCase() {
+        return noteCase;
+    }
+
+    public void setNoteCase(NoteCase noteCase) {
+        this.noteCase = noteCase;
+    }
+
+    @Override
+    public String toString() {
+        MoreObjects.ToStringHelper helper = MoreObjects.toStringHelper("Order");
+        helper.add("id", id).add("state", state).add("items", items).add("labels", labels);
+        helper.add("itemsByLine", itemsByLine).add("signature", signature.length + " bytes");
+        helper.add("note", note).add("cardToken", cardToken).add("voucherCode", voucherCode);
+        helper.add("total", total).add("accepted", accepted);
+        return helper.toString();
+    }
+
+    // @@protoc_insertion_point(class_scope:shop.order.Order)
+}
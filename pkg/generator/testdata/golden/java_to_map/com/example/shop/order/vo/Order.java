@@ -0,0 +1,452 @@
+package com.example.shop.order.vo;
+
+// Code generated by protoc-gen-bean. DO NOT EDIT.
+//
+//     shop/order.proto
+//
+
+import com.example.shop.common.vo.Currency;
+import com.example.shop.common.vo.Money;
+
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+
+// An order placed by a customer
+public class Order {
+    private String id = "";
+    private Order.State state = null;
+    private List<Order.Item> items = new ArrayList<>();
+    private Map<String, String> labels = new HashMap<>();
+    private Map<Integer, Order.Item> itemsByLine = new HashMap<>();
+    private byte[] signature = new byte[]{};
+    private String note = null;
+    private String cardToken = null;
+    private String voucherCode = null;
+    private Money total = null;
+    private List<Currency> accepted = new ArrayList<>();
+
+    public enum PaymentCase {
+        CARD_TOKEN(8),
+        VOUCHER_CODE(9),
+        PAYMENT_NOT_SET(0);
+
+        private final int code;
+
+        PaymentCase(int code) {
+            this.code = code;
+        }
+
+        public int getCode() {
+            return code;
+        }
+
+        public static PaymentCase forNumber(int value) {
+            switch (value) {
+                case 8:
+                    return CARD_TOKEN;
+                case 9:
+                    return VOUCHER_CODE;
+                default:
+                    return PAYMENT_NOT_SET;
+            }
+        }
+    }
+
+    private PaymentCase paymentCase = PaymentCase.PAYMENT_NOT_SET;
+
+    public enum NoteCase {
+        NOTE(7),
+        NOTE_NOT_SET(0);
+
+        private final int code;
+
+        NoteCase(int code) {
+            this.code = code;
+        }
+
+        public int getCode() {
+            return code;
+        }
+
+        public static NoteCase forNumber(int value) {
+            switch (value) {
+                case 7:
+                    return NOTE;
+                default:
+                    return NOTE_NOT_SET;
+            }
+        }
+    }
+
+    private NoteCase noteCase = NoteCase.NOTE_NOT_SET;
+
+    // State of the order
+    // Reserved value numbers: 3
+    public enum State {
+        STATE_UNKNOWN(0, "STATE_UNKNOWN"),
+        PLACED(1, "PLACED"),
+        SHIPPED(2, "SHIPPED");
+
+        private final int code;
+        private final String protoName;
+
+        State(int code, String protoName) {
+            this.code = code;
+            this.protoName = protoName;
+        }
+
+        public int getCode() {
+            return code;
+        }
+
+        /**
+         * Returns the name of the value in the proto file.
+         */
+        public String getProtoName() {
+            return protoName;
+        }
+
+        /**
+         * @deprecated Use {@link #forNumber(int)} instead.
+         */
+        @java.lang.Deprecated
+        public static State valueOf(int value) {
+            return forNumber(value);
+        }
+
+        public static State forNumber(int value) {
+            switch (value) {
+                case 1:
+                    return PLACED;
+                case 2:
+                    return SHIPPED;
+                default:
+                    return STATE_UNKNOWN;
+            }
+        }
+
+        public static State fromName(String name) {
+            if (name == null) {
+                return STATE_UNKNOWN;
+            }
+            switch (name) {
+                case "STATE_UNKNOWN":
+                    return STATE_UNKNOWN;
+                case "PLACED":
+                    return PLACED;
+                case "SHIPPED":
+                    return SHIPPED;
+                default:
+                    return STATE_UNKNOWN;
+            }
+        }
+
+        // @@protoc_insertion_point(enum_scope:shop.order.Order.State)
+    }
+
+    // A line of the order
+    public static class Item {
+        private String sku = "";
+        private int quantity = 0;
+        private Money price = null;
+
+        /**
+         * Returns a new bean of the properties keyed by the names of their fields as returned by {@link #toMap},
+         * leaving the properties missing from the map or null to their defaults. Numbers may be of any type.
+         */
+        @SuppressWarnings("unchecked")
+        public static Item fromMap(Map<String, ?> map) {
+            Item bean = new Item();
+            if (map.get("sku") != null) {
+                bean.setSku((String) map.get("sku"));
+            }
+            if (map.get("quantity") != null) {
+                bean.setQuantity(((Number) map.get("quantity")).intValue());
+            }
+            if (map.get("price") != null) {
+                bean.setPrice(Money.fromMap((Map<String, ?>) map.get("price")));
+            }
+            return bean;
+        }
+
+        public String getSku() {
+            return sku;
+        }
+
+        public void setSku(String sku) {
+            this.sku = sku;
+        }
+
+        public int getQuantity() {
+            return quantity;
+        }
+
+        public void setQuantity(int quantity) {
+            this.quantity = quantity;
+        }
+
+        public Money getPrice() {
+            return price;
+        }
+
+        public void setPrice(Money price) {
+            this.price = price;
+        }
+
+        /**
+         * Returns the properties keyed by the names of their fields, beans as maps of their own and enums as the names of
+         * their constants, see {@link #fromMap}.
+         */
+        public Map<String, Object> toMap() {
+            Map<String, Object> map = new LinkedHashMap<>();
+            map.put("sku", this.sku);
+            map.put("quantity", this.quantity);
+            map.put("price", this.price == null ? null : this.price.toMap());
+            return map;
+        }
+
+        @Override
+        public String toString() {
+            StringBuilder sb = new StringBuilder("Item{");
+            sb.append("sku='").append(sku).append('\'').append(", quantity=").append(quantity);
+            sb.append(", price=").append(price);
+            return sb.append('}').toString();
+        }
+
+        // @@protoc_insertion_point(class_scope:shop.order.Order.Item)
+    }
+
+    /**
+     * Returns a new bean of the properties keyed by the names of their fields as returned by {@link #toMap},
+     * leaving the properties missing from the map or null to their defaults. Numbers may be of any type.
+     */
+    @SuppressWarnings("unchecked")
+    public static Order fromMap(Map<String, ?> map) {
+        Order bean = new Order();
+        if (map.get("id") != null) {
+            bean.setId((String) map.get("id"));
+        }
+        if (map.get("state") != null) {
+            bean.setState(Order.State.valueOf((String) map.get("state")));
+        }
+        if (map.get("items") != null) {
+            List<Order.Item> items = new ArrayList<>();
+            for (Object v : (List<?>) map.get("items")) {
+                items.add(Order.Item.fromMap((Map<String, ?>) v));
+            }
+            bean.setItems(items);
+        }
+        if (map.get("labels") != null) {
+            Map<String, String> labels = new HashMap<>();
+            for (Map.Entry<?, ?> e : ((Map<?, ?>) map.get("labels")).entrySet()) {
+                labels.put((String) e.getKey(), (String) e.getValue());
+            }
+            bean.setLabels(labels);
+        }
+        if (map.get("items_by_line") != null) {
+            Map<Integer, Order.Item> itemsByLine = new HashMap<>();
+            for (Map.Entry<?, ?> e : ((Map<?, ?>) map.get("items_by_line")).entrySet()) {
+                itemsByLine.put(((Number) e.getKey()).intValue(), Order.Item.fromMap((Map<String, ?>) e.getValue()));
+            }
+            bean.setItemsByLine(itemsByLine);
+        }
+        if (map.get("signature") != null) {
+            bean.setSignature((byte[]) map.get("signature"));
+        }
+        if (map.get("note") != null) {
+            bean.setNote((String) map.get("note"));
+            bean.setNoteCase(Order.NoteCase.NOTE);
+        }
+        if (map.get("card_token") != null) {
+            bean.setCardToken((String) map.get("card_token"));
+            bean.setPaymentCase(Order.PaymentCase.CARD_TOKEN);
+        }
+        if (map.get("voucher_code") != null) {
+            bean.setVoucherCode((String) map.get("voucher_code"));
+            bean.setPaymentCase(Order.PaymentCase.VOUCHER_CODE);
+        }
+        if (map.get("total") != null) {
+            bean.setTotal(Money.fromMap((Map<String, ?>) map.get("total")));
+        }
+        if (map.get("accepted") != null) {
+            List<Currency> accepted = new ArrayList<>();
+            for (Object v : (List<?>) map.get("accepted")) {
+                accepted.add(Currency.valueOf((String) v));
+            }
+            bean.setAccepted(accepted);
+        }
+        return bean;
+    }
+
+    public String getId() {
+        return id;
+    }
+
+    public void setId(String id) {
+        this.id = id;
+    }
+
+    public Order.State getState() {
+        return state;
+    }
+
+    public void setState(Order.State state) {
+        this.state = state;
+    }
+
+    public List<Order.Item> getItems() {
+        return items;
+    }
+
+    public void setItems(List<Order.Item> items) {
+        this.items = items;
+    }
+
+    public Map<String, String> getLabels() {
+        return labels;
+    }
+
+    public void setLabels(Map<String, String> labels) {
+        this.labels = labels;
+    }
+
+    public Map<Integer, Order.Item> getItemsByLine() {
+        return itemsByLine;
+    }
+
+    public void setItemsByLine(Map<Integer, Order.Item> itemsByLine) {
+        this.itemsByLine = itemsByLine;
+    }
+
+    public byte[] getSignature() {
+        return signature;
+    }
+
+    public void setSignature(byte[] signature) {
+        this.signature = signature;
+    }
+
+    public String getNote() {
+        return note;
+    }
+
+    public void setNote(String note) {
+        this.note = note;
+        if (note != null) {
+            this.noteCase = NoteCase.NOTE;
+        } else if (this.noteCase == NoteCase.NOTE) {
+            this.noteCase = NoteCase.NOTE_NOT_SET;
+        }
+    }
+
+    public String getCardToken() {
+        return cardToken;
+    }
+
+    public void setCardToken(String cardToken) {
+        this.cardToken = cardToken;
+        if (cardToken != null) {
+            this.paymentCase = PaymentCase.CARD_TOKEN;
+            this.voucherCode = null;
+        } else if (this.paymentCase == PaymentCase.CARD_TOKEN) {
+            this.paymentCase = PaymentCase.PAYMENT_NOT_SET;
+        }
+    }
+
+    public String getVoucherCode() {
+        return voucherCode;
+    }
+
+    public void setVoucherCode(String voucherCode) {
+        this.voucherCode = voucherCode;
+        if (voucherCode != null) {
+            this.paymentCase = PaymentCase.VOUCHER_CODE;
+            this.cardToken = null;
+        } else if (this.paymentCase == PaymentCase.VOUCHER_CODE) {
+            this.paymentCase = PaymentCase.PAYMENT_NOT_SET;
+        }
+    }
+
+    public Money getTotal() {
+        return total;
+    }
+
+    public void setTotal(Money total) {
+        this.total = total;
+    }
+
+    public List<Currency> getAccepted() {
+        return accepted;
+    }
+
+    public void setAccepted(List<Currency> accepted) {
+        this.accepted = accepted;
+    }
+
+    /**
+     * Returns the properties keyed by the names of their fields, beans as maps of their own and enums as the names of
+     * their constants, see {@link #fromMap}.
+     */
+    public Map<String, Object> toMap() {
+        Map<String, Object> map = new LinkedHashMap<>();
+        map.put("id", this.id);
+        map.put("state", this.state == null ? null : this.state.name());
+        List<Object> items = new ArrayList<>();
+        for (Order.Item v : this.items) {
+            items.add(v.toMap());
+        }
+        map.put("items", items);
+        map.put("labels", new LinkedHashMap<>(this.labels));
+        Map<Object, Object> itemsByLine = new LinkedHashMap<>();
+        for (Map.Entry<Integer, Order.Item> e : this.itemsByLine.entrySet()) {
+            itemsByLine.put(e.getKey(), e.getValue().toMap());
+        }
+        map.put("items_by_line", itemsByLine);
+        map.put("signature", this.signature);
+        map.put("note", this.note);
+        map.put("card_token", this.cardToken);
+        map.put("voucher_code", this.voucherCode);
+        map.put("total", this.total == null ? null : this.total.toMap());
+        List<Object> accepted = new ArrayList<>();
+        for (Currency v : this.accepted) {
+            accepted.add(v.name());
+        }
+        map.put("accepted", accepted);
+        return map;
+    }
+
+    public PaymentCase getPaymentCase() {
+        return paymentCase;
+    }
+
+    public void setPaymentCase(PaymentCase paymentCase) {
+        this.paymentCase = paymentCase;
+    }
+
+    public NoteCase getNoteCase() {
+        return noteCase;
+    }
+
+    public void setNoteCase(NoteCase noteCase) {
+        this.noteCase = noteCase;
+    }
+
+    @Override
+    public String toString() {
+        StringBuilder sb = new StringBuilder("Order{");
+        sb.append("id='").append(id).append('\'').append(", state=").append(state);
+        sb.append(", items=").append(items).append(", labels=").append(labels);
+        sb.append(", itemsByLine=").append(itemsByLine);
+        sb.append(", signature=").append(signature.length).append(" bytes");
+        sb.append(", note='").append(note).append('\'');
+        sb.append(", cardToken='").append(cardToken).append('\'');
+        sb.append(", voucherCode='").append(voucherCode).append('\'');
+        sb.append(", total=").append(total).append(", accepted=").append(accepted);
+        return sb.append('}').toString();
+    }
+
+    // @@protoc_insertion_point(class_scope:shop.order.Order)
+}
@@ -0,0 +1,22 @@
+package com.example.shop.legacy.vo;
+
+// Code generated by protoc-gen-bean. DO NOT EDIT.
+//
+//     shop/legacy.proto
+//
+
+import java.util.List;
+import java.util.Map;
+
+/**
+ * The read-only view of {@link Stock}, the getters of its properties.
+ */
+public interface StockView {
+    String getSku();
+
+    int getCount();
+
+    List<Stock.Bin> getBin();
+
+    Map<String, Object> getExtensions();
+}
@@ -0,0 +1,43 @@
+package com.example.shop.order.vo;
+
+// Code generated by protoc-gen-bean. DO NOT EDIT.
+//
+//     shop/order.proto
+//
+
+import com.example.shop.common.vo.Currency;
+import com.example.shop.common.vo.Money;
+
+import java.util.List;
+import java.util.Map;
+
+/**
+ * The read-only view of {@link Order}, the getters of its properties.
+ */
+public interface OrderView {
+    String getId();
+
+    Order.State getState();
+
+    List<Order.Item> getItems();
+
+    Map<String, String> getLabels();
+
+    Map<Integer, Order.Item> getItemsByLine();
+
+    byte[] getSignature();
+
+    String getNote();
+
+    String getCardToken();
+
+    String getVoucherCode();
+
+    Money getTotal();
+
+    List<Currency> getAccepted();
+
+    Order.PaymentCase getPaymentCase();
+
+    Order.NoteCase getNoteCase();
+}
@@ -0,0 +1,35 @@
+package com.example.shop.common.vo
+
+// Code generated by protoc-gen-bean. DO NOT EDIT.
+//
+//     shop/common.proto
+//
+
+// Currency of an amount
+enum class Currency(var code: Int, val protoName: String) {
+    CURRENCY_UNSPECIFIED(0, "CURRENCY_UNSPECIFIED"),
+    USD(1, "USD"),
+    EUR(2, "EUR");
+
+    companion object {
+        fun forNumber(value: Int): Currency {
+            return when (value) {
+                CURRENCY_UNSPECIFIED.code -> CURRENCY_UNSPECIFIED
+                USD.code -> USD
+                EUR.code -> EUR
+                else -> CURRENCY_UNSPECIFIED
+            }
+        }
+
+        fun fromName(name: String?): Currency {
+            return when (name) {
+                "CURRENCY_UNSPECIFIED" -> CURRENCY_UNSPECIFIED
+                "USD" -> USD
+                "EUR" -> EUR
+                else -> CURRENCY_UNSPECIFIED
+            }
+        }
+    }
+
+    // @@protoc_insertion_point(enum_scope:shop.common.Currency)
+}
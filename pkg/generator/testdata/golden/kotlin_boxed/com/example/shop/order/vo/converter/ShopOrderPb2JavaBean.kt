@@ -0,0 +1,179 @@
+package com.example.shop.order.vo.converter
+
+// Code generated by protoc-gen-bean. DO NOT EDIT.
+//
+//     shop/order.proto
+//
+
+import com.example.shop.order.vo.Order
+
+object ShopOrderPb2JavaBean {
+    @JvmStatic
+    fun toBean(pb: com.example.shop.order.OrderOuterClass.Order.State): Order.State {
+        if (pb == com.example.shop.order.OrderOuterClass.Order.State.UNRECOGNIZED) {
+            return Order.State.forNumber(-1)
+        }
+        return Order.State.forNumber(pb.getNumber())
+    }
+
+    @JvmStatic
+    fun toPb(bean: Order.State): com.example.shop.order.OrderOuterClass.Order.State {
+        return com.example.shop.order.OrderOuterClass.Order.State.forNumber(bean.code) ?: com.example.shop.order.OrderOuterClass.Order.State.values()[0]
+    }
+
+    @JvmStatic
+    fun toBean(pb: com.example.shop.order.OrderOuterClass.Order): Order {
+        val bean = Order()
+        bean.id = pb.getId()
+        bean.state = toBean(pb.getState())
+        bean.items = pb.getItemsList().map { toBean(it) }
+        bean.labels = pb.getLabelsMap().toMap()
+        bean.itemsByLine = pb.getItemsByLineMap().mapValues { toBean(it.value) }
+        bean.signature = pb.getSignature().toByteArray()
+        if (pb.hasNote()) {
+            bean.note = pb.getNote()
+            bean.noteCase = Order.NoteCase.NOTE
+        }
+        when (pb.getPaymentCase()) {
+            com.example.shop.order.OrderOuterClass.Order.PaymentCase.CARD_TOKEN -> bean.cardToken = pb.getCardToken()
+            com.example.shop.order.OrderOuterClass.Order.PaymentCase.VOUCHER_CODE -> bean.voucherCode = pb.getVoucherCode()
+            else -> {}
+        }
+        bean.paymentCase = Order.PaymentCase.forNumber(pb.getPaymentCase().getNumber())
+        if (pb.hasTotal()) {
+            bean.total = com.example.shop.common.vo.converter.CommonProtoPb2JavaBean.toBean(pb.getTotal())
+        }
+        bean.accepted = pb.getAcceptedList().map { com.example.shop.common.vo.converter.CommonProtoPb2JavaBean.toBean(it) }
+        return bean
+    }
+
+    @JvmStatic
+    fun toPb(bean: Order): com.example.shop.order.OrderOuterClass.Order {
+        val builder = com.example.shop.order.OrderOuterClass.Order.newBuilder()
+        builder.setId(bean.id)
+        bean.state?.let { builder.setState(toPb(it)) }
+        builder.addAllItems(bean.items.map { toPb(it) })
+        builder.putAllLabels(bean.labels)
+        builder.putAllItemsByLine(bean.itemsByLine.mapValues { toPb(it.value) })
+        builder.setSignature(com.google.protobuf.ByteString.copyFrom(bean.signature))
+        bean.note?.let { builder.setNote(it) }
+        when (bean.paymentCase) {
+            Order.PaymentCase.CARD_TOKEN -> {
+                bean.cardToken?.let { builder.setCardToken(it) }
+            }
+            Order.PaymentCase.VOUCHER_CODE -> {
+                bean.voucherCode?.let { builder.setVoucherCode(it) }
+            }
+            else -> {}
+        }
+        bean.total?.let { builder.setTotal(com.example.shop.common.vo.converter.CommonProtoPb2JavaBean.toPb(it)) }
+        builder.addAllAccepted(bean.accepted.map { com.example.shop.common.vo.converter.CommonProtoPb2JavaBean.toPb(it) })
+        return builder.build()
+    }
+
+    @JvmStatic
+    fun toOrder(data: ByteArray): Order {
+        return toBean(com.example.shop.order.OrderOuterClass.Order.parseFrom(data))
+    }
+
+    @JvmStatic
+    fun toByteArray(bean: Order): ByteArray {
+        return toPb(bean).toByteArray()
+    }
+
+    @JvmStatic
+    fun toOrder(input: java.io.InputStream): Order {
+        return toBean(com.example.shop.order.OrderOuterClass.Order.parseFrom(input))
+    }
+
+    /**
+     * Reads the next length-delimited message from the stream, returns null at the end of the stream.
+     */
+    @JvmStatic
+    fun readDelimitedOrder(input: java.io.InputStream): Order? {
+        val pb = com.example.shop.order.OrderOuterClass.Order.parseDelimitedFrom(input) ?: return null
+        return toBean(pb)
+    }
+
+    /**
+     * Lazily reads length-delimited messages until the end of the stream.
+     */
+    @JvmStatic
+    fun readAllDelimitedOrder(input: java.io.InputStream): Sequence<Order> {
+        return generateSequence { readDelimitedOrder(input) }
+    }
+
+    @JvmStatic
+    fun writeTo(bean: Order, output: java.io.OutputStream) {
+        toPb(bean).writeTo(output)
+    }
+
+    @JvmStatic
+    fun writeDelimitedTo(bean: Order, output: java.io.OutputStream) {
+        toPb(bean).writeDelimitedTo(output)
+    }
+
+    @JvmStatic
+    fun toBean(pb: com.example.shop.order.OrderOuterClass.Order.Item): Order.Item {
+        val bean = Order.Item()
+        bean.sku = pb.getSku()
+        bean.quantity = pb.getQuantity()
+        if (pb.hasPrice()) {
+            bean.price = com.example.shop.common.vo.converter.CommonProtoPb2JavaBean.toBean(pb.getPrice())
+        }
+        return bean
+    }
+
+    @JvmStatic
+    fun toPb(bean: Order.Item): com.example.shop.order.OrderOuterClass.Order.Item {
+        val builder = com.example.shop.order.OrderOuterClass.Order.Item.newBuilder()
+        builder.setSku(bean.sku)
+        bean.quantity?.let { builder.setQuantity(it) }
+        bean.price?.let { builder.setPrice(com.example.shop.common.vo.converter.CommonProtoPb2JavaBean.toPb(it)) }
+        return builder.build()
+    }
+
+    @JvmStatic
+    fun toOrderItem(data: ByteArray): Order.Item {
+        return toBean(com.example.shop.order.OrderOuterClass.Order.Item.parseFrom(data))
+    }
+
+    @JvmStatic
+    fun toByteArray(bean: Order.Item): ByteArray {
+        return toPb(bean).toByteArray()
+    }
+
+    @JvmStatic
+    fun toOrderItem(input: java.io.InputStream): Order.Item {
+        return toBean(com.example.shop.order.OrderOuterClass.Order.Item.parseFrom(input))
+    }
+
+    /**
+     * Reads the next length-delimited message from the stream, returns null at the end of the stream.
+     */
+    @JvmStatic
+    fun readDelimitedOrderItem(input: java.io.InputStream): Order.Item? {
+        val pb = com.example.shop.order.OrderOuterClass.Order.Item.parseDelimitedFrom(input) ?: return null
+        return toBean(pb)
+    }
+
+    /**
+     * Lazily reads length-delimited messages until the end of the stream.
+     */
+    @JvmStatic
+    fun readAllDelimitedOrderItem(input: java.io.InputStream): Sequence<Order.Item> {
+        return generateSequence { readDelimitedOrderItem(input) }
+    }
+
+    @JvmStatic
+    fun writeTo(bean: Order.Item, output: java.io.OutputStream) {
+        toPb(bean).writeTo(output)
+    }
+
+    @JvmStatic
+    fun writeDelimitedTo(bean: Order.Item, output: java.io.OutputStream) {
+        toPb(bean).writeDelimitedTo(output)
+    }
+
+    // @@protoc_insertion_point(converter_scope:shop/order.proto)
+}
@@ -0,0 +1,169 @@
+package com.example.shop.order.vo
+
+// Code generated by protoc-gen-bean. DO NOT EDIT.
+//
+//     shop/order.proto
+//
+
+import com.example.shop.common.vo.Currency
+import com.example.shop.common.vo.Money
+
+// An order placed by a customer
+class Order {
+    var id: String = ""
+    var state: Order.State? = null
+    var items: List<Order.Item> = emptyList()
+    var labels: Map<String, String> = mapOf()
+    var itemsByLine: Map<Int, Order.Item> = mapOf()
+    var signature: ByteArray = byteArrayOf()
+    var note: String? = null
+        set(value) {
+            field = value
+            if (value != null) {
+                noteCase = NoteCase.NOTE
+            } else if (noteCase == NoteCase.NOTE) {
+                noteCase = NoteCase.NOTE_NOT_SET
+            }
+        }
+    var cardToken: String? = null
+        set(value) {
+            field = value
+            if (value != null) {
+                paymentCase = PaymentCase.CARD_TOKEN
+                voucherCode = null
+            } else if (paymentCase == PaymentCase.CARD_TOKEN) {
+                paymentCase = PaymentCase.PAYMENT_NOT_SET
+            }
+        }
+    var voucherCode: String? = null
+        set(value) {
+            field = value
+            if (value != null) {
+                paymentCase = PaymentCase.VOUCHER_CODE
+                cardToken = null
+            } else if (paymentCase == PaymentCase.VOUCHER_CODE) {
+                paymentCase = PaymentCase.PAYMENT_NOT_SET
+            }
+        }
+    var total: Money? = null
+    var accepted: List<Currency> = emptyList()
+
+    enum class PaymentCase(val code: Int) {
+        CARD_TOKEN(8),
+        VOUCHER_CODE(9),
+        PAYMENT_NOT_SET(0);
+
+        companion object {
+            fun forNumber(value: Int): PaymentCase {
+                return when (value) {
+                    CARD_TOKEN.code -> CARD_TOKEN
+                    VOUCHER_CODE.code -> VOUCHER_CODE
+                    else -> PAYMENT_NOT_SET
+                }
+            }
+        }
+    }
+
+    var paymentCase: PaymentCase = PaymentCase.PAYMENT_NOT_SET
+
+    enum class NoteCase(val code: Int) {
+        NOTE(7),
+        NOTE_NOT_SET(0);
+
+        companion object {
+            fun forNumber(value: Int): NoteCase {
+                return when (value) {
+                    NOTE.code -> NOTE
+                    else -> NOTE_NOT_SET
+                }
+            }
+        }
+    }
+
+    var noteCase: NoteCase = NoteCase.NOTE_NOT_SET
+
+    // State of the order
+    // Reserved value numbers: 3
+    enum class State(var code: Int, val protoName: String) {
+        STATE_UNKNOWN(0, "STATE_UNKNOWN"),
+        PLACED(1, "PLACED"),
+        SHIPPED(2, "SHIPPED");
+
+        companion object {
+            fun forNumber(value: Int): State {
+                return when (value) {
+                    STATE_UNKNOWN.code -> STATE_UNKNOWN
+                    PLACED.code -> PLACED
+                    SHIPPED.code -> SHIPPED
+                    else -> STATE_UNKNOWN
+                }
+            }
+
+            fun fromName(name: String?): State {
+                return when (name) {
+                    "STATE_UNKNOWN" -> STATE_UNKNOWN
+                    "PLACED" -> PLACED
+                    "SHIPPED" -> SHIPPED
+                    else -> STATE_UNKNOWN
+                }
+            }
+        }
+
+        // @@protoc_insertion_point(enum_scope:shop.order.Order.State)
+    }
+
+    // A line of the order
+    class Item {
+        var sku: String = ""
+        var quantity: Int = 0
+        var price: Money? = null
+
+        override fun toString(): String {
+            val sb = StringBuilder("Item{")
+            sb.append("sku='").append(sku).append('\'').append(", quantity=").append(quantity)
+            sb.append(", price=").append(price)
+            return sb.append('}').toString()
+        }
+
+        // @@protoc_insertion_point(class_scope:shop.order.Order.Item)
+    }
+
+    fun addItem(value: Order.Item) {
+        this.items = this.items + value
+    }
+
+    fun addAllItems(values: Collection<Order.Item>) {
+        this.items = this.items + values
+    }
+
+    fun putLabel(key: String, value: String) {
+        this.labels = this.labels + (key to value)
+    }
+
+    fun putItemsByLine(key: Int, value: Order.Item) {
+        this.itemsByLine = this.itemsByLine + (key to value)
+    }
+
+    fun addAccepted(value: Currency) {
+        this.accepted = this.accepted + value
+    }
+
+    fun addAllAccepted(values: Collection<Currency>) {
+        this.accepted = this.accepted + values
+    }
+
+    override fun toString(): String {
+        val sb = StringBuilder("Order{")
+        sb.append("id='").append(id).append('\'').append(", state=").append(state)
+        sb.append(", items=").append(items).append(", labels=").append(labels)
+        sb.append(", itemsByLine=").append(itemsByLine)
+        sb.append(", signature=").append(signature.size).append(" bytes")
+        sb.append(", note='").append(note).append('\'')
+        sb.append(", cardToken='").append(cardToken).append('\'')
+        sb.append(", voucherCode='").append(voucherCode).append('\'')
+        sb.append(", total=").append(total).append(", accepted=").append(accepted)
+        return sb.append('}').toString()
+    }
+
+    // @@protoc_insertion_point(class_scope:shop.order.Order)
+}
@@ -0,0 +1,39 @@
+package com.example.shop.common.vo
+
+// Code generated by protoc-gen-bean. DO NOT EDIT.
+//
+//     shop/common.proto
+//
+
+// Currency of an amount
+// source: shop/common.proto:9
+enum class Currency(var code: Int, val protoName: String) {
+    // source: shop/common.proto:10
+    CURRENCY_UNSPECIFIED(0, "CURRENCY_UNSPECIFIED"),
+    // source: shop/common.proto:11
+    USD(1, "USD"),
+    // source: shop/common.proto:12
+    EUR(2, "EUR");
+
+    companion object {
+        fun forNumber(value: Int): Currency {
+            return when (value) {
+                CURRENCY_UNSPECIFIED.code -> CURRENCY_UNSPECIFIED
+                USD.code -> USD
+                EUR.code -> EUR
+                else -> CURRENCY_UNSPECIFIED
+            }
+        }
+
+        fun fromName(name: String?): Currency {
+            return when (name) {
+                "CURRENCY_UNSPECIFIED" -> CURRENCY_UNSPECIFIED
+                "USD" -> USD
+                "EUR" -> EUR
+                else -> CURRENCY_UNSPECIFIED
+            }
+        }
+    }
+
+    // @@protoc_insertion_point(enum_scope:shop.common.Currency)
+}
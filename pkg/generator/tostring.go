@@ -0,0 +1,129 @@
+package generator
+
+// The styles of the generated toString, chosen by the to_string parameter
+const (
+	toStringBuilder = "builder" // StringBuilder statements wrapped at line_width, the default
+	toStringJoiner  = "joiner"  // java.util.StringJoiner, a statement per property
+	toStringGuava   = "guava"   // Guava's MoreObjects.toStringHelper
+	toStringNone    = "none"    // No toString, e.g. for huge messages
+)
+
+// toStringValue is how toString prints the value of a property, whatever the style
+type toStringValue struct {
+	Label  string // Label of the value, escaped for a string literal
+	Expr   string // Expression of the value, empty when redacted
+	Quote  bool   // Whether the value is quoted, for strings
+	Suffix string // Printed after the value, e.g. " bytes" after the size of a byte array
+}
+
+// parseToString validates the to_string parameter
+func (g *Generator) parseToString(v string) string {
+	switch v {
+	case toStringBuilder, toStringJoiner, toStringGuava, toStringNone:
+		return v
+	}
+	g.Fail("invalid to_string", v+", use builder, joiner, guava or none")
+	return ""
+}
+
+// toStringLocal returns the local variable toString builds the string with
+func (g *Generator) toStringLocal() string {
+	switch g.ToString {
+	case toStringJoiner:
+		return "joiner"
+	case toStringGuava:
+		return "helper"
+	}
+	return "sb"
+}
+
+// toStringRef returns the expression reading the property in toString, qualified when the local variable shadows it
+func (g *Generator) toStringRef(f *JavaField) string {
+	if f.Name == g.toStringLocal() {
+		return "this." + f.Name
+	}
+	return f.Name
+}
+
+// toStringIndent returns the column of the statements of the toString of the bean,
+// nested beans are indented within their enclosing classes and the cycle guard adds a try block
+func toStringIndent(c *JavaClass) int {
+	depth := len(c.Desc.TypeName()) + 1
+	if c.Recursive {
+		depth++
+	}
+	return depth * len(DefaultIndent)
+}
+
+// toStringLines returns the statements adding the values of the properties to the local variable of toString,
+// the templates declare the variable and return the string
+func (g *Generator) toStringLines(c *JavaClass, value func(f *JavaField) toStringValue) []string {
+	end := ""
+	if g.lang == LangJava {
+		end = ";"
+	}
+	local := g.toStringLocal()
+	switch g.ToString {
+	case toStringJoiner:
+		lines := make([]string, len(c.Fields))
+		for i, f := range c.Fields {
+			lines[i] = local + ".add(" + joinerEntry(value(f)) + ")" + end
+		}
+		return lines
+	case toStringGuava:
+		chains := make([]string, len(c.Fields))
+		for i, f := range c.Fields {
+			chains[i] = g.guavaCall(value(f))
+		}
+		return wrapCalls(local, chains, end, toStringIndent(c), g.LineWidth)
+	}
+	chains := make([]string, len(c.Fields))
+	for i, f := range c.Fields {
+		chains[i] = builderCalls(i, value(f))
+	}
+	return wrapCalls(local, chains, end, toStringIndent(c), g.LineWidth)
+}
+
+// builderCalls returns the calls appending the label and the value to the StringBuilder, separated from the previous one
+func builderCalls(i int, v toStringValue) string {
+	label := v.Label + "="
+	if i > 0 {
+		label = ", " + label
+	}
+	switch {
+	case v.Expr == "":
+		return `append("` + label + `<redacted>")`
+	case v.Quote:
+		return `append("` + label + `'").append(` + v.Expr + `).append('\'')`
+	case v.Suffix != "":
+		return `append("` + label + `").append(` + v.Expr + `).append("` + v.Suffix + `")`
+	}
+	return `append("` + label + `").append(` + v.Expr + `)`
+}
+
+// joinerEntry returns the string expression of the label and the value added to the StringJoiner
+func joinerEntry(v toStringValue) string {
+	switch {
+	case v.Expr == "":
+		return `"` + v.Label + `=<redacted>"`
+	case v.Quote:
+		return `"` + v.Label + `='" + ` + v.Expr + ` + "'"`
+	case v.Suffix != "":
+		return `"` + v.Label + `=" + ` + v.Expr + ` + "` + v.Suffix + `"`
+	}
+	return `"` + v.Label + `=" + ` + v.Expr
+}
+
+// guavaCall returns the call adding the label and the value to the ToStringHelper, which leaves strings unquoted
+func (g *Generator) guavaCall(v toStringValue) string {
+	switch {
+	case v.Expr == "":
+		return `add("` + v.Label + `", "<redacted>")`
+	case v.Suffix != "" && g.lang == LangJava:
+		return `add("` + v.Label + `", ` + v.Expr + ` + "` + v.Suffix + `")`
+	case v.Suffix != "":
+		// kotlin does not add strings to numbers
+		return `add("` + v.Label + `", "${` + v.Expr + `}` + v.Suffix + `")`
+	}
+	return `add("` + v.Label + `", ` + v.Expr + `)`
+}
@@ -0,0 +1,17 @@
+package generator_test
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestToStringNone checks no toString is generated with to_string=none, the other styles have golden files
+func TestToStringNone(t *testing.T) {
+	for _, parameter := range []string{"to_string=none", "lang=java,to_string=none"} {
+		for _, f := range runFixtures(t, parameter).File {
+			if strings.Contains(f.GetContent(), "toString()") {
+				t.Errorf("%s: %s has a toString", parameter, f.GetName())
+			}
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// validateFiles checks the descriptors of the request for what protoc guarantees but the generator relies on,
+// so that a malformed request, e.g. one built by hand or by another compiler, fails with a message instead of a crash.
+// It must be called after the type name map is built.
+func (g *Generator) validateFiles() {
+	for _, file := range g.allFiles {
+		if file.GetName() == "" {
+			g.Fail("file without a name")
+		}
+		for _, enum := range file.enum {
+			name := protoFullName(enum)
+			if enum.GetName() == "" {
+				g.FailAt(file, enum.path, "", "enum without a name")
+			}
+			if len(enum.Value) == 0 {
+				g.FailAt(file, enum.path, name, "enum has no values")
+			}
+			for i, value := range enum.Value {
+				path := fmt.Sprintf("%s,%d,%d", enum.path, enumValuePath, i)
+				if value.GetName() == "" {
+					g.FailAt(file, path, name, "value without a name")
+				}
+				if value.Number == nil {
+					g.FailAt(file, path, name+"."+value.GetName(), "value has no number")
+				}
+			}
+		}
+		for _, d := range file.desc {
+			g.validateMessage(file, d)
+		}
+	}
+}
+
+// validateMessage checks the fields of a message and, for a map entry, its key and value
+func (g *Generator) validateMessage(file *FileDescriptor, d *Descriptor) {
+	if d.GetName() == "" {
+		g.FailAt(file, d.path, "", "message without a name")
+	}
+	name := protoFullName(d)
+	if d.GetOptions().GetMapEntry() {
+		if len(d.Field) != 2 || d.Field[0].GetNumber() != 1 || d.Field[1].GetNumber() != 2 {
+			g.FailAt(file, d.path, name, "map entry must have a key field 1 and a value field 2")
+		}
+	}
+	for i, field := range d.Field {
+		path := fmt.Sprintf("%s,%d,%d", d.path, messageFieldPath, i)
+		fieldName := name + "." + field.GetName()
+		if field.GetName() == "" {
+			g.FailAt(file, path, name, "field without a name")
+		}
+		if field.Number == nil {
+			g.FailAt(file, path, fieldName, "field has no number")
+		}
+		if field.Type == nil {
+			g.FailAt(file, path, fieldName, "field has no type")
+		}
+		if field.OneofIndex != nil && (field.GetOneofIndex() < 0 || int(field.GetOneofIndex()) >= len(d.OneofDecl)) {
+			g.FailAt(file, path, fieldName, "refers to oneof", fmt.Sprint(field.GetOneofIndex()), "which is not declared")
+		}
+		switch field.GetType() {
+		case descriptor.FieldDescriptorProto_TYPE_MESSAGE, descriptor.FieldDescriptorProto_TYPE_GROUP:
+			if obj, ok := g.typeNameToObject[field.GetTypeName()]; ok {
+				if _, ok := obj.(*Descriptor); !ok {
+					g.FailAt(file, path, fieldName, "field of message type refers to enum", field.GetTypeName())
+				}
+			} else if !field.GetOptions().GetWeak() {
+				g.failUndefinedType(file, path, fieldName, field.GetTypeName())
+			}
+		case descriptor.FieldDescriptorProto_TYPE_ENUM:
+			obj, ok := g.typeNameToObject[field.GetTypeName()]
+			if !ok {
+				g.failUndefinedType(file, path, fieldName, field.GetTypeName())
+			}
+			if _, ok := obj.(*EnumDescriptor); !ok {
+				g.FailAt(file, path, fieldName, "field of enum type refers to message", field.GetTypeName())
+			}
+		default:
+			if _, ok := descriptor.FieldDescriptorProto_Type_name[int32(field.GetType())]; !ok {
+				g.FailAt(file, path, fieldName, "field has unknown type", fmt.Sprint(int32(field.GetType())))
+			}
+		}
+	}
+}
+
+// failUndefinedType reports the reference of the element at the path to an undefined type, suggesting the types meant
+func (g *Generator) failUndefinedType(file *FileDescriptor, path, element, typeName string) {
+	err := schemaError(file, path, element, "refers to undefined type", typeName)
+	err.Suggestion = g.suggestType(file, typeName)
+	g.failWith(err)
+}
+
+// declaredClass is a class the generation declares, with the proto element it comes from
+type declaredClass struct {
+	kind    string // What the class is, e.g. bean
+	file    *FileDescriptor
+	path    string // SourceCodeInfo path of the element
+	element string // Full name of the element, empty for the package of a file without one
+}
+
+// checkCollisions fails when two protos produce the same bean or converter class, e.g. messages of the same name
+// in sibling packages mapped into one vopkg, as one class would otherwise silently overwrite the other.
+// It must be called once the types are selected.
+func (g *Generator) checkCollisions() {
+	classes := make(map[string]declaredClass)
+	declare := func(class string, c declaredClass) {
+		prev, ok := classes[class]
+		if !ok {
+			classes[class] = c
+			return
+		}
+		at := schemaError(prev.file, prev.path, "").location()
+		of := prev.element
+		if of == "" {
+			// declared by a file without a package
+			of = "the file"
+		}
+		err := schemaError(c.file, c.path, c.element, c.kind, class, "collides with the", prev.kind, "of", of, "at", at)
+		if c.kind == "converter" && prev.kind == "converter" {
+			err.Suggestion = "set a different java_outer_classname in one of the files, or map them to different packages with M or pkgmap"
+		} else {
+			err.Suggestion = "rename one of them with a name in the messages of the config file, or map them to different packages with M or pkgmap"
+		}
+		g.failWith(err)
+	}
+	for _, file := range g.genFiles {
+		enums, descs := g.fileEnums(file), g.fileDescriptors(file)
+		if !g.NoBeans {
+			for _, e := range enums {
+				if e.parent == nil {
+					declare(enumImportPath(g, e), declaredClass{"bean", file, e.path, protoFullName(e)})
+				}
+			}
+			for _, d := range descs {
+				if d.parent == nil {
+					declare(descriptorImportPath(g, d), declaredClass{"bean", file, d.path, protoFullName(d)})
+				}
+			}
+			if g.Views {
+				// the views are declared next to the beans, nested ones among the nested beans and enums
+				for _, e := range enums {
+					if e.parent != nil {
+						declare(enumImportPath(g, e), declaredClass{"bean", file, e.path, protoFullName(e)})
+					}
+				}
+				for _, d := range descs {
+					if d.parent != nil {
+						declare(descriptorImportPath(g, d), declaredClass{"bean", file, d.path, protoFullName(d)})
+					}
+				}
+				for _, d := range descs {
+					declare(descriptorImportPath(g, d)+"View", declaredClass{"view", file, d.path, protoFullName(d)})
+				}
+			}
+			for _, imp := range g.publicImports(file) {
+				if _, ok := imp.o.(*EnumDescriptor); ok && g.lang == LangJava {
+					// not re-exported
+					continue
+				}
+				class := file.importPath.String() + "." + g.beanName(imp.o)
+				declare(class, declaredClass{"re-export", file, strconv.Itoa(packagePath), protoFullName(imp.o)})
+			}
+		}
+		if !g.NoBeans && g.Visitor && file == g.genFiles[0] {
+			declare(g.visitorPackage()+"."+fieldVisitorName, declaredClass{"visitor", file, strconv.Itoa(packagePath), file.GetPackage()})
+		}
+		if g.Descriptors && len(enums)+len(descs) > 0 {
+			// once per package, declared again by the other files of the package
+			if _, ok := classes[file.importPath.String()+"."+protoDescriptorsName]; !ok {
+				declare(file.importPath.String()+"."+protoDescriptorsName, declaredClass{"descriptors", file, strconv.Itoa(packagePath), file.GetPackage()})
+			}
+		}
+		if !g.NoConverters && len(enums)+len(descs) > 0 {
+			declare(g.converterPackage(file)+"."+javaConverterName(file), declaredClass{"converter", file, strconv.Itoa(packagePath), file.GetPackage()})
+		}
+	}
+}
+
+// Limits of the names of the generated files, those of the common file systems
+const (
+	maxOutputSegment = 255  // Bytes of a directory or file name
+	maxOutputPath    = 4096 // Bytes of the whole path
+)
+
+// checkOutputPath returns the name of a generated file with its . segments dropped. It fails on names protoc or
+// the file system would misplace or reject, e.g. a .. segment from an odd java_package escaping the output directory.
+func (g *Generator) checkOutputPath(name string) string {
+	fail := func(reason string) {
+		g.Fail(fmt.Sprintf("invalid output file %q, %s, check java_package, vopkg and the M and pkgmap parameters", name, reason))
+	}
+	switch {
+	case name == "":
+		fail("the name is empty")
+	case strings.HasPrefix(name, "/"):
+		fail("the path is absolute")
+	case len(name) > maxOutputPath:
+		fail(fmt.Sprintf("the path is longer than %d bytes", maxOutputPath))
+	}
+	segments := make([]string, 0)
+	for _, s := range strings.Split(name, "/") {
+		switch {
+		case s == ".":
+			continue
+		case s == "":
+			fail("it has an empty directory name, e.g. from consecutive dots in a package")
+		case s == "..":
+			fail("it leaves the output directory")
+		case len(s) > maxOutputSegment:
+			fail(fmt.Sprintf("%.16s... is longer than %d bytes", s, maxOutputSegment))
+		}
+		if i := strings.IndexFunc(s, invalidPathRune); i >= 0 {
+			fail(fmt.Sprintf("%q is not allowed in file names", s[i:i+1]))
+		}
+		segments = append(segments, s)
+	}
+	if len(segments) == 0 {
+		fail("the name is empty")
+	}
+	return strings.Join(segments, "/")
+}
+
+// invalidPathRune reports whether the character is rejected by the file system of one of the common platforms
+func invalidPathRune(r rune) bool {
+	return r < ' ' || r == 0x7f || strings.ContainsRune(`\:*?"<>|`, r)
+}
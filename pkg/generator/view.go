@@ -0,0 +1,10 @@
+package generator
+
+// viewProperties returns the properties declared by the read-only view of the bean, with views=true.
+// The view is declared next to the bean rather than in it, the cases of its oneofs are qualified by the bean.
+func viewProperties(c *JavaClass, props []javaProperty) []javaProperty {
+	for i, o := range c.Oneofs {
+		props[len(c.Fields)+i].Type = c.Name + "." + o.CaseName()
+	}
+	return props
+}
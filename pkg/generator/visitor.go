@@ -0,0 +1,62 @@
+package generator
+
+import "strings"
+
+// fieldVisitorName is the name of the interface the beans call back with their properties, with visitor=true
+const fieldVisitorName = "FieldVisitor"
+
+// beanVisit is the visit method of a bean, calling the field visitor back with every property
+type beanVisit struct {
+	Visitor string          // Name referring to the FieldVisitor interface
+	Fields  []fieldMetadata // Fields of the message, in the order of the properties
+}
+
+// visitorPackage returns the package of the FieldVisitor interface, generated once along the beans of the first file
+func (g *Generator) visitorPackage() string {
+	return g.genFiles[0].importPath.String()
+}
+
+// fieldVisitorRef returns the name referring to the FieldVisitor interface in the source file being generated
+func (g *Generator) fieldVisitorRef() string {
+	pkg := g.visitorPackage()
+	if pkg == "" {
+		return fieldVisitorName
+	}
+	return g.AddImport(pkg + "." + fieldVisitorName)
+}
+
+// beanInterfaces returns the interfaces implemented by the bean, separated by commas,
+// its read-only view with views=true and FieldVisitor.Visitable with visitor=true
+func (g *Generator) beanInterfaces(c *JavaClass) string {
+	interfaces := make([]string, 0, 2)
+	if c.View != "" {
+		interfaces = append(interfaces, c.View)
+	}
+	if g.Visitor {
+		interfaces = append(interfaces, g.fieldVisitorRef()+".Visitable")
+	}
+	return strings.Join(interfaces, ", ")
+}
+
+// beanVisit returns the visit method of the bean, nil without visitor=true
+func (g *Generator) beanVisit(c *JavaClass, fields []fieldMetadata) *beanVisit {
+	if !g.Visitor {
+		return nil
+	}
+	return &beanVisit{Visitor: g.fieldVisitorRef(), Fields: fields}
+}
+
+// generateFieldVisitor writes the FieldVisitor interface
+func (g *Generator) generateFieldVisitor() {
+	g.Reset()
+	pkg := g.visitorPackage()
+	if g.lang == LangJava {
+		populatePreamble(g, "package "+pkg+";", g.genFiles...)
+	} else {
+		populatePreamble(g, "package "+pkg, g.genFiles...)
+	}
+	g.beginImports(pkg, fieldVisitorName)
+	g.render("fieldVisitor", nil)
+	g.printImports()
+	g.addOutputFile(g.outputFileName(g.genFiles[0], pkg, fieldVisitorName), g.genFiles, nil)
+}